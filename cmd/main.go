@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,7 +11,16 @@ import (
 	"syscall"
 	"time"
 
+	projectCalendar "github.com/Jamolkhon5/mistral/internal/ai/project/calendar"
+	projectClient "github.com/Jamolkhon5/mistral/internal/ai/project/client"
+	projectDrafts "github.com/Jamolkhon5/mistral/internal/ai/project/drafts"
 	projectAI "github.com/Jamolkhon5/mistral/internal/ai/project/handler"
+	projectOutbox "github.com/Jamolkhon5/mistral/internal/ai/project/outbox"
+	projectTemplates "github.com/Jamolkhon5/mistral/internal/ai/project/templates"
+	projectValidator "github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
+	taskClient "github.com/Jamolkhon5/mistral/internal/ai/task/client"
+	taskAI "github.com/Jamolkhon5/mistral/internal/ai/task/handler"
 	"github.com/Jamolkhon5/mistral/internal/auth"
 	"github.com/Jamolkhon5/mistral/internal/config"
 	"github.com/Jamolkhon5/mistral/internal/handler"
@@ -64,13 +74,49 @@ func main() {
 	// Инициализация репозитория и обработчиков
 	repo := repository.NewRepository(db)
 	chatHandler := handler.NewHandler(repo, cfg.MistralApiKey, cfg.ModelName)
-	projectAssistant := projectAI.NewProjectAssistantHandler(cfg.MistralApiKey, cfg.ModelName)
+
+	projectFlow, err := wizard.LoadProjectFlow(cfg.ProjectWizardPath)
+	if err != nil {
+		log.Fatal("Ошибка загрузки описания мастера проектов:", err)
+	}
+	if err := projectCalendar.SetDefault(cfg.CalendarCountry); err != nil {
+		log.Fatal("Ошибка загрузки производственного календаря:", err)
+	}
+	if err := projectValidator.SetCharacterPolicy(cfg.ProjectTextPolicy); err != nil {
+		log.Fatal("Ошибка настройки проверки проектов:", err)
+	}
+	templateStore, err := projectTemplates.NewStore(db)
+	if err != nil {
+		log.Fatal("Ошибка загрузки шаблонов проектов:", err)
+	}
+	// Очередь создания проектов и фоновый обработчик, который повторяет неудачные попытки
+	projects := projectClient.NewClient(cfg.ProjectServiceURL, cfg.ProjectServiceToken)
+	creations := projectOutbox.NewStore(db)
+	dispatcher := projectOutbox.NewDispatcher(creations, projects)
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	if dispatcher.CanRunInBackground() {
+		go dispatcher.Run(dispatcherCtx)
+	} else {
+		log.Println("PROJECT_SERVICE_TOKEN не задан: фоновые повторы создания проектов отключены, проекты создаются только в запросе пользователя")
+	}
+
+	// Черновики мастера и фоновое удаление устаревших
+	drafts := projectDrafts.NewStore(db)
+	go projectDrafts.NewExpirer(drafts, cfg.ProjectDraftRetention).Run(dispatcherCtx)
+
+	projectAssistant, err := projectAI.NewProjectAssistantHandler(cfg.MistralApiKey, cfg.ModelName, projectFlow, templateStore, creations, dispatcher, drafts, projects)
+	if err != nil {
+		log.Fatal("Ошибка инициализации AI-ассистента проектов:", err)
+	}
+
+	taskAssistant := taskAI.NewTaskAssistantHandler(taskClient.NewClient(cfg.TaskServiceURL))
 
 	// Настройка роутера
 	router := setupRouter()
 
 	// Регистрация маршрутов
-	registerRoutes(router, chatHandler, projectAssistant)
+	registerRoutes(router, chatHandler, projectAssistant, taskAssistant)
 
 	// Настройка и запуск сервера
 	server := setupServer(router)
@@ -78,8 +124,16 @@ func main() {
 	// Запуск сервера в горутине
 	go startServer(server)
 
+	// Служебный сервер с метриками слушает отдельный адрес и не публикуется вместе с API
+	servers := []*http.Server{server}
+	if cfg.AdminAddr != "" {
+		adminServer := setupAdminServer(cfg.AdminAddr)
+		go startServer(adminServer)
+		servers = append(servers, adminServer)
+	}
+
 	// Ожидание сигнала для graceful shutdown
-	waitForShutdown(server)
+	waitForShutdown(servers...)
 }
 
 func waitForDatabase(dbURL string) (*sqlx.DB, error) {
@@ -117,6 +171,39 @@ func initializeTables(db *sqlx.DB) error {
             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
             updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
         )`,
+		`ALTER TABLE project_conversations ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft'`,
+		`CREATE INDEX IF NOT EXISTS project_conversations_user_status_idx ON project_conversations (user_id, status, updated_at)`,
+		`CREATE TABLE IF NOT EXISTS project_creation_outbox (
+            id SERIAL PRIMARY KEY,
+            session_id INTEGER NOT NULL REFERENCES project_conversations (id),
+            user_id VARCHAR(255) NOT NULL,
+            creation_key VARCHAR(64) NOT NULL UNIQUE,
+            payload JSONB NOT NULL,
+            status VARCHAR(20) NOT NULL DEFAULT 'pending',
+            attempts INTEGER NOT NULL DEFAULT 0,
+            project_id VARCHAR(255) NOT NULL DEFAULT '',
+            last_error TEXT NOT NULL DEFAULT '',
+            next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            locked_until TIMESTAMP,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        )`,
+		`CREATE INDEX IF NOT EXISTS project_creation_outbox_due_idx ON project_creation_outbox (status, next_attempt_at)`,
+		`CREATE TABLE IF NOT EXISTS project_templates (
+            id SERIAL PRIMARY KEY,
+            scope VARCHAR(20) NOT NULL,
+            owner_id VARCHAR(255) NOT NULL,
+            created_by VARCHAR(255) NOT NULL,
+            name VARCHAR(255) NOT NULL,
+            description TEXT NOT NULL DEFAULT '',
+            priority VARCHAR(50) NOT NULL DEFAULT '',
+            deadline_offset_days INTEGER NOT NULL DEFAULT 0,
+            confidentiality VARCHAR(100) NOT NULL DEFAULT '',
+            team_roles JSONB NOT NULL DEFAULT '[]',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        )`,
+		`CREATE INDEX IF NOT EXISTS project_templates_owner_idx ON project_templates (scope, owner_id)`,
 	}
 
 	for _, query := range queries {
@@ -140,6 +227,7 @@ func initializeAuthService() (*grpc.ClientConn, error) {
 	}
 
 	auth.InitClient(conn)
+	auth.ConfigureTokenCache(authConfig.TokenCacheTTL, authConfig.TokenCacheNegativeTTL, authConfig.TokenCacheSize)
 	return conn, nil
 }
 
@@ -167,23 +255,35 @@ func setupRouter() *chi.Mux {
 	return router
 }
 
-func registerRoutes(r *chi.Mux, chatHandler *handler.Handler, projectAssistant *projectAI.ProjectAssistantHandler) {
+func registerRoutes(r *chi.Mux, chatHandler *handler.Handler, projectAssistant *projectAI.ProjectAssistantHandler, taskAssistant *taskAI.TaskAssistantHandler) {
 	r.Route("/v1", func(r chi.Router) {
-		// Middleware для проверки Content-Type
-		r.Use(middleware.AllowContentType("application/json"))
 		r.Use(middleware.SetHeader("Content-Type", "application/json"))
 
-		// Основные эндпоинты чата
-		r.Post("/chat", chatHandler.Chat)
-		r.Post("/clear-history", chatHandler.ClearHistory)
+		// Загрузка брифа принимает как JSON, так и файл в multipart/form-data
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AllowContentType("application/json", "multipart/form-data"))
+			projectAssistant.RegisterUploadRoutes(r)
+		})
+
+		r.Group(func(r chi.Router) {
+			// Middleware для проверки Content-Type
+			r.Use(middleware.AllowContentType("application/json"))
+
+			// Основные эндпоинты чата
+			r.Post("/chat", chatHandler.Chat)
+			r.Post("/clear-history", chatHandler.ClearHistory)
+
+			// Эндпоинты AI-ассистента проектов
+			projectAssistant.RegisterRoutes(r)
 
-		// Эндпоинты AI-ассистента проектов
-		projectAssistant.RegisterRoutes(r)
+			// Эндпоинты AI-ассистента задач
+			taskAssistant.RegisterRoutes(r)
 
-		// Health check
-		r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK"))
+			// Health check
+			r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("OK"))
+			})
 		})
 	})
 }
@@ -198,6 +298,21 @@ func setupServer(router *chi.Mux) *http.Server {
 	}
 }
 
+// setupAdminServer создает служебный сервер с метриками в формате expvar
+// (в том числе счетчиками кэша проверки токенов)
+func setupAdminServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	return &http.Server{
+		Addr:         addr,
+		Handler:      mux,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+}
+
 func startServer(srv *http.Server) {
 	log.Printf("Сервер запущен на порту %s\n", srv.Addr)
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -205,7 +320,7 @@ func startServer(srv *http.Server) {
 	}
 }
 
-func waitForShutdown(srv *http.Server) {
+func waitForShutdown(servers ...*http.Server) {
 	// Канал для получения сигналов операционной системы
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -218,9 +333,11 @@ func waitForShutdown(srv *http.Server) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Пытаемся gracefully остановить сервер
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Ошибка при остановке сервера:", err)
+	// Пытаемся gracefully остановить серверы
+	for _, srv := range servers {
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Fatal("Ошибка при остановке сервера:", err)
+		}
 	}
 
 	log.Println("Сервер успешно остановлен")
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	projectDrafts "github.com/Jamolkhon5/mistral/internal/ai/project/drafts"
+	projectAI "github.com/Jamolkhon5/mistral/internal/ai/project/handler"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
+	taskClient "github.com/Jamolkhon5/mistral/internal/ai/task/client"
+	taskAI "github.com/Jamolkhon5/mistral/internal/ai/task/handler"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+	"github.com/Jamolkhon5/mistral/internal/handler"
+	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeAuth принимает любой токен и возвращает одного и того же пользователя.
+// Запросы профиля по идентификатору подсчитываются.
+type fakeAuth struct {
+	auth_v1.UnimplementedAuthV1Server
+	lookups atomic.Int64
+}
+
+func (*fakeAuth) GetUser(context.Context, *emptypb.Empty) (*auth_v1.GetUserInfoResponse, error) {
+	return &auth_v1.GetUserInfoResponse{User: &auth_v1.User{Id: "42", Country: "US"}}, nil
+}
+
+func (f *fakeAuth) GetUserById(context.Context, *auth_v1.GetUserByIdRequest) (*auth_v1.GetUserByIdResponse, error) {
+	f.lookups.Add(1)
+	return &auth_v1.GetUserByIdResponse{User: &auth_v1.User{Id: "42", Country: "US"}}, nil
+}
+
+// fakeMistral отвечает на запросы к Mistral и запоминает текст последнего запроса
+type fakeMistral struct {
+	content string
+	request string
+}
+
+func (f *fakeMistral) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.request = string(body)
+
+	response, err := json.Marshal(map[string]interface{}{
+		"choices": []map[string]interface{}{{"message": map[string]string{"content": f.content}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(response)),
+		Request:    req,
+	}, nil
+}
+
+func startFakeAuth(t *testing.T) *fakeAuth {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	fake := &fakeAuth{}
+	server := grpc.NewServer()
+	auth_v1.RegisterAuthV1Server(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial auth: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	auth.InitClient(conn)
+	return fake
+}
+
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+
+	flow, err := wizard.LoadProjectFlow("")
+	if err != nil {
+		t.Fatalf("load flow: %v", err)
+	}
+	// База недоступна: сохранение черновика только логирует ошибку
+	db, err := sql.Open("postgres", "host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	drafts := projectDrafts.NewStore(sqlx.NewDb(db, "postgres"))
+
+	projectAssistant, err := projectAI.NewProjectAssistantHandler("key", "model", flow, nil, nil, nil, drafts, nil)
+	if err != nil {
+		t.Fatalf("project assistant: %v", err)
+	}
+
+	router := setupRouter()
+	registerRoutes(router, handler.NewHandler(nil, "key", "model"), projectAssistant, taskAI.NewTaskAssistantHandler(taskClient.NewClient("")))
+	return router
+}
+
+// buildDocx собирает минимальный документ Word с указанными абзацами
+func buildDocx(t *testing.T, paragraphs ...string) []byte {
+	t.Helper()
+
+	var body strings.Builder
+	for _, paragraph := range paragraphs {
+		body.WriteString(`<w:p><w:r><w:t>` + paragraph + `</w:t></w:r></w:p>`)
+	}
+
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`},
+		{"word/document.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` + body.String() + `</w:body></w:document>`},
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			t.Fatalf("docx: %v", err)
+		}
+		if _, err := writer.Write([]byte(file.content)); err != nil {
+			t.Fatalf("docx: %v", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("docx: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestImportBriefDocxThroughRouter(t *testing.T) {
+	startFakeAuth(t)
+
+	mistral := &fakeMistral{content: `{"name":"Портал поставщиков","description":"","deadline":"","priority":"","budget":"","spent":"","status":"","confidentiality":"","team":[]}`}
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = mistral
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	router := newTestRouter(t)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "brief.docx")
+	if err != nil {
+		t.Fatalf("form: %v", err)
+	}
+	file.Write(buildDocx(t, "Проект: Портал поставщиков", "Срок: конец квартала"))
+	form.WriteField("locale", "ru")
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ai/project/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer test")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(mistral.request, "Портал поставщиков") || !strings.Contains(mistral.request, "Срок: конец квартала") {
+		t.Errorf("текст DOCX не передан модели: %s", mistral.request)
+	}
+
+	var response models.AssistantResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.ProjectContext.ProjectData.Name != "Портал поставщиков" {
+		t.Errorf("name = %q", response.ProjectContext.ProjectData.Name)
+	}
+}
+
+// Язык без явного указания определяется по стране из профиля, полученного при проверке токена,
+// без отдельного запроса пользователя по идентификатору
+func TestLocaleFromVerifiedProfile(t *testing.T) {
+	fake := startFakeAuth(t)
+	router := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ai/project/validate", strings.NewReader(`{"description":"short"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer locale-test")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if strings.ContainsAny(recorder.Body.String(), "абвгдежзиклмнопрстуфхцчшщыэюя") {
+		t.Errorf("ответ не на английском: %s", recorder.Body.String())
+	}
+	if got := fake.lookups.Load(); got != 0 {
+		t.Errorf("GetUserById вызван %d раз, want 0", got)
+	}
+}
+
+func TestJSONRoutesRejectOtherContentTypes(t *testing.T) {
+	router := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ai/project/chat", strings.NewReader("message=hi"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestDebugVarsNotServedByPublicRouter(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, path := range []string{"/debug/vars", "/v1/debug/vars"} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("GET %s status = %d, want %d", path, recorder.Code, http.StatusNotFound)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	setupAdminServer("127.0.0.1:0").Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "auth_token_cache") {
+		t.Errorf("admin /debug/vars status = %d, body has auth_token_cache = %v", recorder.Code, strings.Contains(recorder.Body.String(), "auth_token_cache"))
+	}
+}
@@ -0,0 +1,193 @@
+// Package calendar содержит производственные календари: выходные дни, официальные праздники
+// и переносы рабочих дней. Календари стран загружаются из встроенных файлов data/<страна>.json.
+package calendar
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCountry - страна календаря по умолчанию
+const DefaultCountry = "RU"
+
+//go:embed data/*.json
+var dataFiles embed.FS
+
+// ErrUnknownCountry возвращается, если для страны нет файла календаря
+var ErrUnknownCountry = errors.New("производственный календарь страны не найден")
+
+// Calendar - производственный календарь страны. Для лет, описанных в файле, используются
+// официальные праздники с переносами; для остальных - выходные и фиксированные праздники.
+type Calendar struct {
+	country       string
+	weekend       map[time.Weekday]bool
+	fixedHolidays map[string]bool
+	holidays      map[int]map[string]bool
+	workingDays   map[int]map[string]bool
+}
+
+// calendarFile - формат файла календаря
+type calendarFile struct {
+	Country       string   `json:"country"`
+	Weekend       []int    `json:"weekend"`
+	FixedHolidays []string `json:"fixed_holidays"` // ММ-ДД
+	Years         map[string]struct {
+		Holidays    []string `json:"holidays"`     // ГГГГ-ММ-ДД, нерабочие дни в будни
+		WorkingDays []string `json:"working_days"` // ГГГГ-ММ-ДД, рабочие дни в выходные
+	} `json:"years"`
+}
+
+var (
+	mu        sync.RWMutex
+	loaded    = map[string]*Calendar{}
+	defaultCC = DefaultCountry
+)
+
+// Load возвращает календарь страны по коду ISO 3166-1, например "RU"
+func Load(country string) (*Calendar, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+
+	mu.RLock()
+	calendar, ok := loaded[country]
+	mu.RUnlock()
+	if ok {
+		return calendar, nil
+	}
+
+	raw, err := dataFiles.ReadFile(path.Join("data", strings.ToLower(country)+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCountry, country)
+	}
+	calendar, err = parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения календаря %s: %w", country, err)
+	}
+
+	mu.Lock()
+	loaded[country] = calendar
+	mu.Unlock()
+	return calendar, nil
+}
+
+// SetDefault выбирает страну календаря, который используется проверками проекта
+func SetDefault(country string) error {
+	if country == "" {
+		country = DefaultCountry
+	}
+	calendar, err := Load(country)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defaultCC = calendar.country
+	mu.Unlock()
+	return nil
+}
+
+// Default возвращает календарь страны по умолчанию
+func Default() *Calendar {
+	mu.RLock()
+	country := defaultCC
+	mu.RUnlock()
+
+	calendar, err := Load(country)
+	if err != nil {
+		// Встроенный календарь по умолчанию всегда есть, ошибка означает поврежденную сборку
+		panic(err)
+	}
+	return calendar
+}
+
+func parse(raw []byte) (*Calendar, error) {
+	var file calendarFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, err
+	}
+
+	calendar := &Calendar{
+		country:       strings.ToUpper(file.Country),
+		weekend:       make(map[time.Weekday]bool, len(file.Weekend)),
+		fixedHolidays: make(map[string]bool, len(file.FixedHolidays)),
+		holidays:      make(map[int]map[string]bool, len(file.Years)),
+		workingDays:   make(map[int]map[string]bool, len(file.Years)),
+	}
+	for _, day := range file.Weekend {
+		calendar.weekend[time.Weekday(day)] = true
+	}
+	for _, day := range file.FixedHolidays {
+		calendar.fixedHolidays[day] = true
+	}
+	for key, year := range file.Years {
+		number, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный год %q", key)
+		}
+		calendar.holidays[number] = toSet(year.Holidays)
+		calendar.workingDays[number] = toSet(year.WorkingDays)
+	}
+	return calendar, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// Country возвращает код страны календаря
+func (c *Calendar) Country() string {
+	return c.country
+}
+
+// IsWorkingDay сообщает, является ли дата рабочим днем
+func (c *Calendar) IsWorkingDay(date time.Time) bool {
+	key := date.Format("2006-01-02")
+	if holidays, ok := c.holidays[date.Year()]; ok {
+		if c.workingDays[date.Year()][key] {
+			return true
+		}
+		return !c.weekend[date.Weekday()] && !holidays[key]
+	}
+	return !c.weekend[date.Weekday()] && !c.fixedHolidays[date.Format("01-02")]
+}
+
+// WorkingDaysBetween возвращает количество рабочих дней от from до to включительно.
+// Время суток не учитывается; если to раньше from, возвращает 0.
+func (c *Calendar) WorkingDaysBetween(from, to time.Time) int {
+	day := truncate(from)
+	end := truncate(to)
+
+	count := 0
+	for !day.After(end) {
+		if c.IsWorkingDay(day) {
+			count++
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return count
+}
+
+// IsFeasible сообщает, успевает ли команда выполнить работу объемом effortDays человеко-дней
+// к сроку to, если начнет в from. Команда меньше одного человека считается одним исполнителем.
+func (c *Calendar) IsFeasible(from, to time.Time, effortDays float64, teamSize int) bool {
+	if teamSize < 1 {
+		teamSize = 1
+	}
+	capacity := c.WorkingDaysBetween(from, to) * teamSize
+	return math.Ceil(effortDays) <= float64(capacity)
+}
+
+func truncate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
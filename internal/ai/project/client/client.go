@@ -0,0 +1,440 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+// DefaultBaseURL используется, если адрес сервиса проектов не задан в конфигурации
+const DefaultBaseURL = "http://project-service:5641"
+
+const (
+	// maxAttempts ограничивает количество попыток создания проекта
+	maxAttempts = 3
+	// retryDelay - задержка перед первой повторной попыткой, дальше она удваивается
+	retryDelay = 500 * time.Millisecond
+	// minAttemptTime - время, которое должно оставаться до дедлайна вызывающего для еще одной попытки
+	minAttemptTime = 2 * time.Second
+	// maxMemberLookupProjects ограничивает количество проектов, просматриваемых при поиске участника по email
+	maxMemberLookupProjects = 20
+	// memberLookupConcurrency ограничивает количество одновременных запросов при поиске участника по email
+	memberLookupConcurrency = 5
+)
+
+// StatusError возвращается, если сервис проектов ответил кодом, отличным от 2xx
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("сервис проектов вернул статус %d: %s", e.StatusCode, e.Body)
+}
+
+// Retryable сообщает, имеет ли смысл повторить запрос
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// IsRetryable сообщает, можно ли повторить запрос после ошибки: сетевые ошибки, 429 и 5xx
+// считаются временными
+func IsRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+	return err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// Credentials определяет, от чьего имени выполняется запрос
+type Credentials struct {
+	// Authorization - заголовок Authorization исходного запроса пользователя
+	Authorization string
+	// UserID передается в заголовке X-User-ID, когда запрос выполняется со служебным токеном
+	UserID string
+}
+
+// Client отправляет запросы к сервису проектов
+type Client struct {
+	baseURL      string
+	serviceToken string
+	httpClient   *http.Client
+}
+
+// NewClient создает клиент сервиса проектов. serviceToken используется, если в запросе
+// пользователя нет собственного токена доступа.
+func NewClient(baseURL, serviceToken string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		serviceToken: serviceToken,
+		httpClient:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// HasServiceToken сообщает, задан ли служебный токен для запросов без токена пользователя
+func (c *Client) HasServiceToken() bool {
+	return c.serviceToken != ""
+}
+
+// CreateProject создает проект и возвращает его идентификатор.
+// creationKey передается в заголовке Idempotency-Key, поэтому повторные попытки
+// с тем же ключом не создают дубликатов.
+func (c *Client) CreateProject(ctx context.Context, credentials Credentials, creationKey string, project *models.ProjectData) (string, error) {
+	projectRequest := projectFields(project, nil)
+	if project.Plan != nil {
+		projectRequest["plan"] = project.Plan
+	}
+
+	jsonData, err := json.Marshal(projectRequest)
+	if err != nil {
+		return "", fmt.Errorf("ошибка при маршалинге проекта: %w", err)
+	}
+
+	var projectID string
+	err = withRetry(ctx, func() error {
+		projectID, err = c.createProject(ctx, credentials, creationKey, jsonData)
+		return err
+	})
+	return projectID, err
+}
+
+// GetProject возвращает текущие данные проекта. Дедлайн приводится к формату ДД.ММ.ГГГГ,
+// который используют валидаторы мастера.
+func (c *Client) GetProject(ctx context.Context, credentials Credentials, projectID string) (*models.ProjectData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v1/projects/"+url.PathEscape(projectID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при создании запроса: %w", err)
+	}
+	c.setCredentials(req, credentials)
+
+	respBody, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return parseProject(respBody)
+}
+
+// ListProjects возвращает проекты, доступные пользователю
+func (c *Client) ListProjects(ctx context.Context, credentials Credentials) ([]models.ProjectSummary, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v1/projects", nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при создании запроса: %w", err)
+	}
+	c.setCredentials(req, credentials)
+
+	respBody, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return parseProjectList(respBody)
+}
+
+// FindMembersByEmail ищет пользователей по email среди участников проектов, доступных пользователю:
+// в командах проектов email и идентификатор хранятся вместе. Возвращает идентификаторы найденных
+// пользователей по email в нижнем регистре. Проекты загружаются параллельно в пределах дедлайна
+// ctx; проекты, которые не удалось загрузить, пропускаются.
+func (c *Client) FindMembersByEmail(ctx context.Context, credentials Credentials, emails []string) (map[string]string, error) {
+	wanted := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		wanted[strings.ToLower(email)] = true
+	}
+
+	projects, err := c.ListProjects(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) > maxMemberLookupProjects {
+		projects = projects[:maxMemberLookupProjects]
+	}
+
+	// Как только найдены все email, оставшиеся запросы отменяются
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		found = make(map[string]string, len(wanted))
+		slots = make(chan struct{}, memberLookupConcurrency)
+	)
+	for _, summary := range projects {
+		wg.Add(1)
+		go func(projectID string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			project, err := c.GetProject(ctx, credentials, projectID)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Ошибка загрузки проекта %s при поиске участников: %v", projectID, err)
+				}
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, member := range project.Team {
+				email := strings.ToLower(member.Email)
+				if wanted[email] && member.ID != "" {
+					found[email] = member.ID
+				}
+			}
+			if len(found) == len(wanted) {
+				cancel()
+			}
+		}(summary.ID)
+	}
+	wg.Wait()
+
+	// Отмена после того, как все найдено, ошибкой не считается
+	if len(found) < len(wanted) {
+		if err := ctx.Err(); err != nil {
+			return found, err
+		}
+	}
+	return found, nil
+}
+
+// UpdateProject изменяет в проекте только перечисленные поля. Запрос PATCH с теми же
+// данными идемпотентен, поэтому временные ошибки повторяются так же, как при создании.
+func (c *Client) UpdateProject(ctx context.Context, credentials Credentials, projectID string, project *models.ProjectData, fields []string) error {
+	jsonData, err := json.Marshal(projectFields(project, fields))
+	if err != nil {
+		return fmt.Errorf("ошибка при маршалинге проекта: %w", err)
+	}
+
+	return withRetry(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, c.baseURL+"/v1/projects/"+url.PathEscape(projectID), bytes.NewReader(jsonData))
+		if err != nil {
+			return fmt.Errorf("ошибка при создании запроса: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		c.setCredentials(req, credentials)
+
+		_, err = c.do(req)
+		return err
+	})
+}
+
+// projectFields собирает тело запроса к сервису проектов. Если fields не пустой,
+// в тело попадают только перечисленные поля. Суммы budget и spent передаются строкой
+// "<сумма> <валюта>" с кодом валюты ISO 4217, например "2000000 RUB"; нулевая сумма - "0".
+func projectFields(project *models.ProjectData, fields []string) map[string]interface{} {
+	all := map[string]interface{}{
+		"name":            project.Name,
+		"description":     project.Description,
+		"deadline":        project.Deadline,
+		"status":          project.Status,
+		"priority":        project.Priority,
+		"team":            project.Team,
+		"budget":          project.Budget,
+		"spent":           project.Spent,
+		"confidentiality": project.Confidentiality,
+		"progress":        project.Progress,
+		"plan":            project.Plan,
+	}
+	if fields == nil {
+		delete(all, "plan")
+		return all
+	}
+
+	selected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected
+}
+
+// withRetry повторяет запрос при временных ошибках, не более maxAttempts раз. Если у ctx есть
+// дедлайн, повтор выполняется, только когда после задержки на попытку остается minAttemptTime.
+func withRetry(ctx context.Context, request func() error) error {
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		err := request()
+		if err == nil || attempt == maxAttempts || !IsRetryable(err) {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay+minAttemptTime {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (c *Client) createProject(ctx context.Context, credentials Credentials, creationKey string, body []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/projects", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("ошибка при создании запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if creationKey != "" {
+		req.Header.Set("Idempotency-Key", creationKey)
+	}
+	c.setCredentials(req, credentials)
+
+	respBody, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+
+	// Проект уже создан, поэтому ошибку разбора ответа не повторяем, а только сообщаем о ней
+	projectID, err := parseProjectID(respBody)
+	if err != nil {
+		log.Printf("Не удалось получить идентификатор созданного проекта: %v", err)
+	}
+	return projectID, nil
+}
+
+// setCredentials передает токен пользователя или, если его нет, служебный токен с X-User-ID
+func (c *Client) setCredentials(req *http.Request, credentials Credentials) {
+	switch {
+	case credentials.Authorization != "":
+		req.Header.Set("Authorization", credentials.Authorization)
+	case c.serviceToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.serviceToken)
+		if credentials.UserID != "" {
+			req.Header.Set("X-User-ID", credentials.UserID)
+		}
+	}
+}
+
+// do выполняет запрос и возвращает тело ответа; ответы с кодом, отличным от 2xx, возвращаются как StatusError
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при отправке запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return respBody, nil
+}
+
+// parseProject разбирает проект из ответа сервиса проектов: на верхнем уровне или в объекте project
+func parseProject(body []byte) (*models.ProjectData, error) {
+	var wrapped struct {
+		Project *models.ProjectData `json:"project"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("ошибка при декодировании проекта: %w", err)
+	}
+
+	project := wrapped.Project
+	if project == nil {
+		project = &models.ProjectData{}
+		if err := json.Unmarshal(body, project); err != nil {
+			return nil, fmt.Errorf("ошибка при декодировании проекта: %w", err)
+		}
+	}
+
+	project.Deadline = normalizeDate(project.Deadline)
+	if project.Team == nil {
+		project.Team = make([]models.TeamMember, 0)
+	}
+	return project, nil
+}
+
+// parseProjectList разбирает список проектов: массив на верхнем уровне или в поле projects либо items
+func parseProjectList(body []byte) ([]models.ProjectSummary, error) {
+	type summary struct {
+		ID   json.RawMessage `json:"id"`
+		Name string          `json:"name"`
+	}
+
+	var list []summary
+	if err := json.Unmarshal(body, &list); err != nil {
+		var wrapped struct {
+			Projects []summary `json:"projects"`
+			Items    []summary `json:"items"`
+		}
+		if err := json.Unmarshal(body, &wrapped); err != nil {
+			return nil, fmt.Errorf("ошибка при декодировании списка проектов: %w", err)
+		}
+		list = wrapped.Projects
+		if list == nil {
+			list = wrapped.Items
+		}
+	}
+
+	projects := make([]models.ProjectSummary, 0, len(list))
+	for _, project := range list {
+		projects = append(projects, models.ProjectSummary{ID: rawID(project.ID), Name: project.Name})
+	}
+	return projects, nil
+}
+
+// normalizeDate приводит дату из ISO 8601 к формату ДД.ММ.ГГГГ; другие значения возвращаются как есть
+func normalizeDate(date string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, date); err == nil {
+			return parsed.Format("02.01.2006")
+		}
+	}
+	return date
+}
+
+// parseProjectID извлекает идентификатор из ответа сервиса проектов. Идентификатор может
+// быть строкой или числом, на верхнем уровне или в объекте project.
+func parseProjectID(body []byte) (string, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return "", nil
+	}
+
+	var created struct {
+		ID      json.RawMessage `json:"id"`
+		Project struct {
+			ID json.RawMessage `json:"id"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("ошибка при декодировании ответа: %w", err)
+	}
+
+	raw := created.ID
+	if len(raw) == 0 {
+		raw = created.Project.ID
+	}
+	return rawID(raw), nil
+}
+
+// rawID возвращает идентификатор, записанный строкой или числом
+func rawID(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id
+	}
+	return strings.TrimSpace(string(raw))
+}
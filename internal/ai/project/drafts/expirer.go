@@ -0,0 +1,54 @@
+package drafts
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// DefaultRetention - срок хранения черновика без изменений, если он не задан в конфигурации
+	DefaultRetention = 30 * 24 * time.Hour
+	// expireInterval - период удаления устаревших черновиков
+	expireInterval = time.Hour
+)
+
+// Expirer периодически удаляет черновики, которые не менялись дольше срока хранения
+type Expirer struct {
+	store     *Store
+	retention time.Duration
+}
+
+func NewExpirer(store *Store, retention time.Duration) *Expirer {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Expirer{store: store, retention: retention}
+}
+
+// Run удаляет устаревшие черновики при запуске и затем раз в expireInterval, пока не будет отменен ctx
+func (e *Expirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+
+	for {
+		e.expire(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Expirer) expire(ctx context.Context) {
+	deleted, err := e.store.DeleteExpired(ctx, e.retention)
+	if err != nil {
+		log.Printf("Ошибка удаления устаревших черновиков проектов: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("Удалено устаревших черновиков проектов: %d", deleted)
+	}
+}
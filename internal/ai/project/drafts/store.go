@@ -0,0 +1,177 @@
+package drafts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// Статусы сессий мастера в таблице project_conversations
+const (
+	SessionDraft   = "draft"   // Незавершенная сессия
+	SessionUpdated = "updated" // Изменения существующего проекта сохранены
+)
+
+var ErrNotFound = errors.New("project draft not found")
+
+// Draft - краткие сведения о незавершенной сессии мастера для списка черновиков
+type Draft struct {
+	ID          int64     `json:"id" db:"id"`
+	CurrentStep string    `json:"current_step" db:"current_step"`
+	Name        string    `json:"name" db:"name"`                       // Название проекта, если пользователь успел его ввести
+	ProjectID   string    `json:"project_id,omitempty" db:"project_id"` // Редактируемый проект; пустой для нового
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Store хранит черновики мастера создания проекта
+type Store struct {
+	db *sqlx.DB
+}
+
+func NewStore(db *sqlx.DB) *Store {
+	return &Store{db: db}
+}
+
+// Save сохраняет контекст мастера как черновик. Новая сессия получает идентификатор,
+// который записывается в контекст. Сессии, которые уже подтверждены, не перезаписываются.
+func (s *Store) Save(ctx context.Context, userID string, dialog *models.ProjectCreationContext) error {
+	if dialog.SessionID != 0 {
+		contextJSON, err := json.Marshal(dialog)
+		if err != nil {
+			return err
+		}
+
+		result, err := s.db.ExecContext(ctx, `
+            UPDATE project_conversations
+            SET context = $3, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1 AND user_id = $2 AND status = $4`, dialog.SessionID, userID, contextJSON, SessionDraft)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения черновика: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows > 0 {
+			return nil
+		}
+
+		var exists bool
+		if err := s.db.GetContext(ctx, &exists, `
+            SELECT EXISTS (SELECT 1 FROM project_conversations WHERE id = $1 AND user_id = $2)`,
+			dialog.SessionID, userID); err != nil {
+			return fmt.Errorf("ошибка сохранения черновика: %w", err)
+		}
+		if exists {
+			return nil
+		}
+		// Черновик удален или истек срок его хранения: сохраняем диалог как новую сессию
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Идентификатор сессии хранится в самом контексте, поэтому сначала создаем запись
+	var sessionID int64
+	if err := tx.GetContext(ctx, &sessionID, `
+        INSERT INTO project_conversations (user_id, context, status)
+        VALUES ($1, '{}', $2)
+        RETURNING id`, userID, SessionDraft); err != nil {
+		return fmt.Errorf("ошибка создания черновика: %w", err)
+	}
+	dialog.SessionID = sessionID
+
+	contextJSON, err := json.Marshal(dialog)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE project_conversations SET context = $2 WHERE id = $1`, sessionID, contextJSON); err != nil {
+		return fmt.Errorf("ошибка создания черновика: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Finish сохраняет итоговый контекст сессии и закрывает черновик с указанным статусом.
+// Сессии, которые не сохранялись как черновик, пропускаются.
+func (s *Store) Finish(ctx context.Context, userID string, dialog *models.ProjectCreationContext, status string) error {
+	if dialog.SessionID == 0 {
+		return nil
+	}
+	contextJSON, err := json.Marshal(dialog)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+        UPDATE project_conversations
+        SET context = $3, status = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND user_id = $2 AND status = $5`, dialog.SessionID, userID, contextJSON, status, SessionDraft)
+	return err
+}
+
+// List возвращает черновики пользователя, начиная с последних измененных
+func (s *Store) List(ctx context.Context, userID string) ([]Draft, error) {
+	drafts := make([]Draft, 0)
+	err := s.db.SelectContext(ctx, &drafts, `
+        SELECT id,
+               COALESCE(context->>'current_step', '') AS current_step,
+               COALESCE(context->'project_data'->>'name', '') AS name,
+               COALESCE(context->>'project_id', '') AS project_id,
+               created_at, updated_at
+        FROM project_conversations
+        WHERE user_id = $1 AND status = $2
+        ORDER BY updated_at DESC`, userID, SessionDraft)
+	return drafts, err
+}
+
+// Get возвращает сохраненный контекст черновика
+func (s *Store) Get(ctx context.Context, userID string, id int64) (*models.ProjectCreationContext, error) {
+	var contextJSON []byte
+	err := s.db.GetContext(ctx, &contextJSON, `
+        SELECT context FROM project_conversations
+        WHERE id = $1 AND user_id = $2 AND status = $3`, id, userID, SessionDraft)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dialog models.ProjectCreationContext
+	if err := json.Unmarshal(contextJSON, &dialog); err != nil {
+		return nil, fmt.Errorf("ошибка разбора черновика %d: %w", id, err)
+	}
+	dialog.SessionID = id
+	return &dialog, nil
+}
+
+// Delete удаляет черновик пользователя
+func (s *Store) Delete(ctx context.Context, userID string, id int64) error {
+	result, err := s.db.ExecContext(ctx, `
+        DELETE FROM project_conversations
+        WHERE id = $1 AND user_id = $2 AND status = $3`, id, userID, SessionDraft)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteExpired удаляет черновики, которые не менялись дольше retention, и возвращает их количество
+func (s *Store) DeleteExpired(ctx context.Context, retention time.Duration) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+        DELETE FROM project_conversations
+        WHERE status = $1 AND updated_at < CURRENT_TIMESTAMP - $2 * INTERVAL '1 second'`,
+		SessionDraft, retention.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
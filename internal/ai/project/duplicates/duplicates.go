@@ -0,0 +1,150 @@
+// Package duplicates ищет среди проектов пользователя проекты с таким же или похожим названием.
+package duplicates
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+const (
+	// Threshold - минимальное сходство названий, при котором проект считается возможным дубликатом
+	Threshold = 0.8
+	// MaxMatches ограничивает количество похожих проектов, которые предлагаются пользователю
+	MaxMatches = 3
+)
+
+// stopWords не влияют на смысл названия: "Проект Альфа" и "Альфа" - один и тот же проект
+var stopWords = map[string]bool{
+	"проект": true, "проекта": true, "project": true, "the": true,
+}
+
+// Find возвращает проекты, названия которых совпадают с name или похожи на него,
+// начиная с самых похожих
+func Find(name string, projects []models.ProjectSummary) []models.ProjectMatch {
+	normalized := NormalizeName(name)
+	if normalized == "" {
+		return nil
+	}
+
+	var matches []models.ProjectMatch
+	for _, project := range projects {
+		other := NormalizeName(project.Name)
+		if other == "" {
+			continue
+		}
+		similarity := Similarity(normalized, other)
+		if similarity < Threshold {
+			continue
+		}
+		matches = append(matches, models.ProjectMatch{
+			ID:         project.ID,
+			Name:       project.Name,
+			Similarity: similarity,
+			Exact:      normalized == other,
+		})
+	}
+
+	// Точные совпадения идут первыми, затем остальные по убыванию сходства
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Exact != matches[j].Exact {
+			return matches[i].Exact
+		}
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	if len(matches) > MaxMatches {
+		matches = matches[:MaxMatches]
+	}
+	return matches
+}
+
+// NormalizeName приводит название к виду для сравнения: нижний регистр, "ё" как "е",
+// без знаков препинания, лишних пробелов и служебных слов
+func NormalizeName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	meaningful := make([]string, 0, len(words))
+	for _, word := range words {
+		if !stopWords[word] {
+			meaningful = append(meaningful, word)
+		}
+	}
+	// Название только из служебных слов сравниваем целиком
+	if len(meaningful) == 0 {
+		meaningful = words
+	}
+	return strings.Join(meaningful, " ")
+}
+
+// Similarity возвращает сходство нормализованных названий от 0 до 1. Учитывается и порядок
+// слов, и их набор, чтобы "Сайт новый" и "Новый сайт" считались похожими.
+func Similarity(a, b string) float64 {
+	direct := ratio(a, b)
+	sorted := ratio(sortWords(a), sortWords(b))
+	if sorted > direct {
+		return sorted
+	}
+	return direct
+}
+
+// ratio - доля совпадения строк по расстоянию Левенштейна
+func ratio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func sortWords(s string) string {
+	words := strings.Fields(s)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// levenshtein считает минимальное количество вставок, удалений и замен символов
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// Warning возвращает предупреждение о проектах с таким же или похожим названием.
+// matches не должен быть пустым.
+func Warning(matches []models.ProjectMatch) validator.Warning {
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.Name)
+	}
+
+	code := "name_similar"
+	if matches[0].Exact {
+		code = "name_duplicate"
+	}
+	return validator.Warning{Field: "name", Code: code, Args: []interface{}{strings.Join(names, "; ")}}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/client"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/outbox"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+)
+
+// createProject ставит подтвержденный проект в очередь создания и сразу пытается доставить его
+// от имени пользователя. Если сервис проектов недоступен, проект будет создан фоновым
+// обработчиком, а клиент может узнать статус по creation_id.
+func (h *ProjectAssistantHandler) createProject(ctx context.Context, r *http.Request, userID string, response *models.AssistantResponse) error {
+	projectContext := &response.ProjectContext
+	locale := i18n.Resolve(i18n.Normalize(projectContext.Locale))
+
+	entry, claimed, err := h.creations.Enqueue(r.Context(), userID, projectContext)
+	if err != nil {
+		return err
+	}
+	response.CreationID = entry.ID
+
+	// Запись, которую уже доставляет фоновый обработчик или параллельный запрос, не трогаем:
+	// клиент узнает результат по creation_id
+	if claimed && entry.Status == outbox.StatusPending {
+		credentials := client.Credentials{Authorization: r.Header.Get("Authorization"), UserID: userID}
+		if err := h.dispatcher.Deliver(ctx, entry, credentials); err != nil {
+			log.Printf("Error creating project, creation %d queued for retry: %v", entry.ID, err)
+		}
+	}
+
+	switch entry.Status {
+	case outbox.StatusDelivered:
+		response.ProjectID = entry.ProjectID
+		response.Message = i18n.T(locale, "assistant.created", projectContext.ProjectData.Name)
+	case outbox.StatusDead:
+		response.SuggestedAction = "project_creation_failed"
+		response.Message = i18n.T(locale, "assistant.creation_failed")
+	case outbox.StatusPending:
+		response.SuggestedAction = "project_creation_pending"
+		response.Message = i18n.T(locale, "assistant.creation_pending")
+		// Без фонового обработчика запись доставит только повторное подтверждение
+		if !h.dispatcher.CanRunInBackground() {
+			response.Message = i18n.T(locale, "assistant.creation_retry")
+		}
+	default:
+		response.SuggestedAction = "project_creation_pending"
+		response.Message = i18n.T(locale, "assistant.creation_pending")
+	}
+
+	return nil
+}
+
+// GetCreationStatus возвращает статус создания проекта из очереди
+func (h *ProjectAssistantHandler) GetCreationStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.VerifyToken(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid creation id", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.creations.Get(r.Context(), userID, id)
+	if errors.Is(err, outbox.ErrNotFound) {
+		http.Error(w, "Creation not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Ошибка получения статуса создания проекта %d: %v", id, err)
+		http.Error(w, "Ошибка сервера", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/drafts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+)
+
+// saveDraft сохраняет диалог как черновик. Ошибка сохранения не должна прерывать диалог,
+// поэтому она только записывается в журнал.
+func (h *ProjectAssistantHandler) saveDraft(r *http.Request, userID string, response *models.AssistantResponse) {
+	if err := h.drafts.Save(r.Context(), userID, &response.ProjectContext); err != nil {
+		log.Printf("Ошибка сохранения черновика проекта пользователя %s: %v", userID, err)
+	}
+}
+
+// discardDraft удаляет черновик диалога, если он был сохранен
+func (h *ProjectAssistantHandler) discardDraft(r *http.Request, userID string, response *models.AssistantResponse) {
+	sessionID := response.ProjectContext.SessionID
+	if sessionID == 0 {
+		return
+	}
+	if err := h.drafts.Delete(r.Context(), userID, sessionID); err != nil && !errors.Is(err, drafts.ErrNotFound) {
+		log.Printf("Ошибка удаления черновика проекта %d: %v", sessionID, err)
+	}
+}
+
+// ListDrafts возвращает незавершенные сессии мастера пользователя
+func (h *ProjectAssistantHandler) ListDrafts(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.VerifyToken(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	list, err := h.drafts.List(r.Context(), userID)
+	if err != nil {
+		log.Printf("Ошибка получения черновиков проектов: %v", err)
+		http.Error(w, "Ошибка сервера", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// ResumeDraft продолжает сессию мастера с того шага, на котором пользователь остановился
+func (h *ProjectAssistantHandler) ResumeDraft(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	userID := user.GetId()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid draft id", http.StatusBadRequest)
+		return
+	}
+
+	dialog, err := h.drafts.Get(r.Context(), userID, id)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	locale := resolveLocale(r, r.URL.Query().Get("locale"), dialog, user)
+	writeJSON(w, http.StatusOK, h.assistant.Resume(dialog, locale))
+}
+
+// DeleteDraft удаляет черновик пользователя
+func (h *ProjectAssistantHandler) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.VerifyToken(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid draft id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.drafts.Delete(r.Context(), userID, id); err != nil {
+		writeDraftError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeDraftError(w http.ResponseWriter, err error) {
+	if errors.Is(err, drafts.ErrNotFound) {
+		http.Error(w, "Draft not found", http.StatusNotFound)
+		return
+	}
+	log.Printf("Ошибка работы с черновиком проекта: %v", err)
+	http.Error(w, "Ошибка сервера", http.StatusInternalServerError)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/client"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/duplicates"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+// findDuplicates ищет среди проектов пользователя проекты с таким же или похожим названием.
+// Если сервис проектов недоступен, проверка пропускается: она не должна мешать созданию проекта.
+func (h *ProjectAssistantHandler) findDuplicates(ctx context.Context, r *http.Request, userID string, projectContext *models.ProjectCreationContext) []models.ProjectMatch {
+	if !h.assistant.NeedsDuplicateCheck(projectContext) {
+		return nil
+	}
+	return h.similarProjects(ctx, r, userID, projectContext.ProjectData.Name)
+}
+
+// similarProjects загружает проекты пользователя и сравнивает их названия с name
+func (h *ProjectAssistantHandler) similarProjects(ctx context.Context, r *http.Request, userID, name string) []models.ProjectMatch {
+	credentials := client.Credentials{Authorization: r.Header.Get("Authorization"), UserID: userID}
+	projects, err := h.projects.ListProjects(ctx, credentials)
+	if err != nil {
+		log.Printf("Не удалось проверить похожие проекты пользователя %s: %v", userID, err)
+		return nil
+	}
+	return duplicates.Find(name, projects)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/client"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/drafts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+// startEdit загружает проект из сервиса проектов и начинает диалог его редактирования
+func (h *ProjectAssistantHandler) startEdit(ctx context.Context, r *http.Request, userID, projectID string, locale i18n.Locale) (*models.AssistantResponse, error) {
+	credentials := client.Credentials{Authorization: r.Header.Get("Authorization"), UserID: userID}
+	project, err := h.projects.GetProject(ctx, credentials, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return h.assistant.StartEdit(projectID, project, locale), nil
+}
+
+// writeEditError сообщает клиенту, что проект для редактирования не удалось загрузить
+func writeEditError(w http.ResponseWriter, projectID string, err error) {
+	var statusErr *client.StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusNotFound:
+			http.Error(w, "Project not found", http.StatusNotFound)
+			return
+		case http.StatusUnauthorized, http.StatusForbidden:
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+	}
+	log.Printf("Ошибка загрузки проекта %s для редактирования: %v", projectID, err)
+	http.Error(w, "Не удалось загрузить проект", http.StatusBadGateway)
+}
+
+// updateProject отправляет в сервис проектов только измененные поля. Если сервис недоступен,
+// правки остаются в черновике и пользователь может повторить подтверждение.
+func (h *ProjectAssistantHandler) updateProject(ctx context.Context, r *http.Request, userID string, response *models.AssistantResponse) {
+	projectContext := &response.ProjectContext
+	locale := i18n.Resolve(i18n.Normalize(projectContext.Locale))
+	changed := models.ChangedFields(projectContext.Original, projectContext.ProjectData)
+
+	credentials := client.Credentials{Authorization: r.Header.Get("Authorization"), UserID: userID}
+	if err := h.projects.UpdateProject(ctx, credentials, projectContext.ProjectID, projectContext.ProjectData, changed); err != nil {
+		log.Printf("Ошибка сохранения изменений проекта %s: %v", projectContext.ProjectID, err)
+		response.SuggestedAction = "project_update_failed"
+		response.Message = i18n.T(locale, "assistant.update_failed")
+		h.saveDraft(r, userID, response)
+		return
+	}
+
+	response.ProjectID = projectContext.ProjectID
+	response.Message = i18n.T(locale, "assistant.updated", projectContext.ProjectData.Name)
+	if err := h.drafts.Finish(r.Context(), userID, projectContext, drafts.SessionUpdated); err != nil {
+		log.Printf("Ошибка закрытия сессии редактирования проекта %s: %v", projectContext.ProjectID, err)
+	}
+}
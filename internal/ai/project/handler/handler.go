@@ -1,41 +1,77 @@
 package handler
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/go-chi/chi/v5"
-	"io/ioutil"
 	"log"
 	"net/http"
+	"time"
 
+	"github.com/Jamolkhon5/mistral/internal/ai/project/client"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/drafts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
 	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/outbox"
 	"github.com/Jamolkhon5/mistral/internal/ai/project/service"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/templates"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
 	"github.com/Jamolkhon5/mistral/internal/auth"
+	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
 )
 
 type ProjectAssistantHandler struct {
-	assistant *service.ProjectAssistant
+	assistant  *service.ProjectAssistant
+	templates  *templates.Store
+	creations  *outbox.Store
+	dispatcher *outbox.Dispatcher
+	drafts     *drafts.Store
+	projects   *client.Client
 }
 
-func NewProjectAssistantHandler(mistralApiKey, modelName string) *ProjectAssistantHandler {
-	return &ProjectAssistantHandler{
-		assistant: service.NewProjectAssistant(mistralApiKey, modelName),
+// projectServiceTimeout ограничивает все синхронные запросы к сервису проектов, сделанные за один
+// запрос клиента, вместе с повторами: ответ должен уйти клиенту раньше WriteTimeout сервера
+// (15 секунд). Проекты, которые не удалось создать за это время, досоздает фоновый обработчик очереди.
+const projectServiceTimeout = 10 * time.Second
+
+// projectServiceContext возвращает контекст запроса с бюджетом времени на обращения к сервису проектов
+func projectServiceContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(r.Context(), projectServiceTimeout)
+}
+
+func NewProjectAssistantHandler(mistralApiKey, modelName string, flow *wizard.Definition, templateStore *templates.Store, creations *outbox.Store, dispatcher *outbox.Dispatcher, draftStore *drafts.Store, projects *client.Client) (*ProjectAssistantHandler, error) {
+	assistant, err := service.NewProjectAssistant(mistralApiKey, modelName, flow)
+	if err != nil {
+		return nil, err
 	}
+
+	return &ProjectAssistantHandler{
+		assistant:  assistant,
+		templates:  templateStore,
+		creations:  creations,
+		dispatcher: dispatcher,
+		drafts:     draftStore,
+		projects:   projects,
+	}, nil
 }
 
 func (h *ProjectAssistantHandler) ChatWithAssistant(w http.ResponseWriter, r *http.Request) {
 	// Проверка авторизации
-	userID, err := auth.VerifyToken(r)
+	user, err := auth.VerifyUser(r)
 	if err != nil {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
+	userID := user.GetId()
 
 	// Декодируем запрос
 	var req struct {
-		Message string                         `json:"message"`
-		Context *models.ProjectCreationContext `json:"context,omitempty"`
+		Message    string                         `json:"message"`
+		Context    *models.ProjectCreationContext `json:"context,omitempty"`
+		Action     string                         `json:"action,omitempty"`      // "back" или "undo" для возврата на предыдущий шаг
+		Locale     string                         `json:"locale,omitempty"`      // Язык диалога (ru, en)
+		TemplateID string                         `json:"template_id,omitempty"` // Шаблон, которым нужно предзаполнить проект
+		ProjectID  string                         `json:"project_id,omitempty"`  // Существующий проект, который нужно отредактировать
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -43,21 +79,65 @@ func (h *ProjectAssistantHandler) ChatWithAssistant(w http.ResponseWriter, r *ht
 		return
 	}
 
+	locale := resolveLocale(r, req.Locale, req.Context, user)
+
+	// Один бюджет времени на весь ход диалога: загрузка проекта, поиск участников, проверка
+	// похожих проектов и создание не должны в сумме выйти за WriteTimeout сервера
+	ctx, cancel := projectServiceContext(r)
+	defer cancel()
+
 	// Обработка сообщения ассистентом
-	response, err := h.assistant.HandleMessage(req.Message, req.Context)
+	var response *models.AssistantResponse
+	switch req.Action {
+	case "":
+		// Новый диалог редактирования начинается с загрузки проекта из сервиса проектов
+		if req.ProjectID != "" && (req.Context == nil || req.Context.ProjectID != req.ProjectID) {
+			response, err = h.startEdit(ctx, r, userID, req.ProjectID, locale)
+			if err != nil {
+				writeEditError(w, req.ProjectID, err)
+				return
+			}
+			break
+		}
+		response, err = h.startFromTemplate(r.Context(), user, req.TemplateID, req.Message, req.Context, locale)
+		if err == nil && response == nil {
+			h.resolveMemberEmails(ctx, r, userID, req.Message, req.Context)
+			response, err = h.assistant.HandleMessage(req.Message, req.Context, locale)
+		}
+	case "back", "undo":
+		response, err = h.assistant.GoBack(req.Context, locale)
+	default:
+		http.Error(w, "Unknown action", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		log.Printf("Error handling message: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Если есть подсказка к действию "create_project", создаем проект
-	if response.SuggestedAction == "create_project" {
-		if err := h.createProject(userID, response.ProjectContext.ProjectData); err != nil {
-			log.Printf("Error creating project: %v", err)
+	// Если есть подсказка к действию "create_project" или "update_project", создаем или изменяем
+	// проект от имени пользователя, иначе сохраняем диалог как черновик, чтобы к нему можно было вернуться
+	switch response.SuggestedAction {
+	case "create_project":
+		// Перед созданием спрашиваем пользователя, если у него уже есть проект с похожим названием
+		if matches := h.findDuplicates(ctx, r, userID, &response.ProjectContext); len(matches) > 0 {
+			response = h.assistant.OfferDuplicates(&response.ProjectContext, matches)
+			h.saveDraft(r, userID, response)
+			break
+		}
+		if err := h.createProject(ctx, r, userID, response); err != nil {
+			log.Printf("Error queueing project creation: %v", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+	case "update_project":
+		h.updateProject(ctx, r, userID, response)
+	case "open_project":
+		// Пользователь выбрал существующий проект, черновик нового больше не нужен
+		h.discardDraft(r, userID, response)
+	default:
+		h.saveDraft(r, userID, response)
 	}
 
 	// Отправляем ответ
@@ -125,56 +205,46 @@ func (h *ProjectAssistantHandler) GenerateDescription(w http.ResponseWriter, r *
 	}
 }
 
-func (h *ProjectAssistantHandler) createProject(userID string, projectData *models.ProjectData) error {
-	// Подготавливаем данные для создания проекта
-	projectRequest := map[string]interface{}{
-		"name":            projectData.Name,
-		"description":     projectData.Description,
-		"deadline":        projectData.Deadline,
-		"status":          projectData.Status,
-		"priority":        projectData.Priority,
-		"team":            projectData.Team,
-		"budget":          projectData.Budget,
-		"spent":           projectData.Spent,
-		"confidentiality": projectData.Confidentiality,
-		"progress":        projectData.Progress,
-	}
-
-	// Создаем JSON
-	jsonData, err := json.Marshal(projectRequest)
-	if err != nil {
-		return err
-	}
-
-	// Отправляем запрос к сервису проектов
-	req, err := http.NewRequest("POST", "http://project-service:5641/v1/projects", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
+// resolveLocale выбирает язык диалога: явно указанный в запросе, сохраненный в контексте,
+// из заголовка Accept-Language или по стране из профиля пользователя, полученного при проверке токена
+func resolveLocale(r *http.Request, requested string, context *models.ProjectCreationContext, user *auth_v1.User) i18n.Locale {
+	if locale := i18n.Normalize(requested); locale != "" {
+		return locale
 	}
-
-	// Добавляем заголовки
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", userID)) // Используем ID пользователя как токен
-
-	// Выполняем запрос
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+	if context != nil {
+		if locale := i18n.Normalize(context.Locale); locale != "" {
+			return locale
+		}
 	}
-	defer resp.Body.Close()
-
-	// Проверяем статус ответа
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create project: %s", string(body))
+	if locale := i18n.FromAcceptLanguage(r.Header.Get("Accept-Language")); locale != "" {
+		return locale
 	}
 
-	return nil
+	return i18n.Resolve(i18n.FromCountry(user.GetCountry()))
 }
 
 // RegisterRoutes регистрирует маршруты для AI-ассистента
 func (h *ProjectAssistantHandler) RegisterRoutes(r chi.Router) {
 	r.Post("/ai/project/chat", h.ChatWithAssistant)
 	r.Post("/ai/project/generate-description", h.GenerateDescription)
+	r.Post("/ai/project/validate", h.ValidateProject)
+	r.Post("/ai/project/plan", h.GeneratePlan)
+	r.Post("/ai/project/risks", h.AssessRisks)
+	r.Get("/ai/project/creations/{id}", h.GetCreationStatus)
+
+	r.Get("/ai/project/drafts", h.ListDrafts)
+	r.Post("/ai/project/drafts/{id}/resume", h.ResumeDraft)
+	r.Delete("/ai/project/drafts/{id}", h.DeleteDraft)
+
+	r.Get("/ai/project/templates", h.ListTemplates)
+	r.Post("/ai/project/templates", h.CreateTemplate)
+	r.Get("/ai/project/templates/{id}", h.GetTemplate)
+	r.Put("/ai/project/templates/{id}", h.UpdateTemplate)
+	r.Delete("/ai/project/templates/{id}", h.DeleteTemplate)
+}
+
+// RegisterUploadRoutes регистрирует маршруты, которые принимают файлы в multipart/form-data.
+// Их нужно подключать вне группы, разрешающей только application/json.
+func (h *ProjectAssistantHandler) RegisterUploadRoutes(r chi.Router) {
+	r.Post("/ai/project/import", h.ImportBrief)
 }
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/importer"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+)
+
+// ImportBrief принимает бриф проекта текстом или файлом (TXT, Markdown, DOCX), извлекает
+// из него данные проекта и открывает сессию мастера на первом незаполненном поле.
+// Файл передается в multipart-поле "file", текст - в JSON-поле "text".
+func (h *ProjectAssistantHandler) ImportBrief(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	userID := user.GetId()
+
+	r.Body = http.MaxBytesReader(w, r.Body, importer.MaxBriefSize+1<<20)
+
+	var brief, requestedLocale string
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		brief, requestedLocale, err = readBriefFile(r)
+	} else {
+		var req struct {
+			Text   string `json:"text"`
+			Locale string `json:"locale"`
+		}
+		if err = json.NewDecoder(r.Body).Decode(&req); err == nil {
+			requestedLocale = req.Locale
+			brief, err = importer.ExtractText("", []byte(req.Text))
+		}
+	}
+	if errors.Is(err, importer.ErrUnsupportedFormat) || errors.Is(err, importer.ErrEmptyBrief) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	locale := resolveLocale(r, requestedLocale, nil, user)
+	response, err := h.assistant.ImportBrief(brief, locale, h.memberLookup(r, userID))
+	if err != nil {
+		log.Printf("Ошибка импорта брифа проекта: %v", err)
+		http.Error(w, "Не удалось разобрать бриф проекта", http.StatusInternalServerError)
+		return
+	}
+
+	h.saveDraft(r, userID, response)
+	writeJSON(w, http.StatusOK, response)
+}
+
+func readBriefFile(r *http.Request) (string, string, error) {
+	if err := r.ParseMultipartForm(importer.MaxBriefSize); err != nil {
+		return "", "", err
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, importer.MaxBriefSize))
+	if err != nil {
+		return "", "", err
+	}
+	text, err := importer.ExtractText(header.Filename, content)
+	return text, r.FormValue("locale"), err
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+)
+
+// GeneratePlan разбивает проект на этапы и задачи с оценками и рекомендуемыми ролями.
+// Для плана в данных проекта должны быть описание и дедлайн.
+func (h *ProjectAssistantHandler) GeneratePlan(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var data models.ProjectData
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	locale := resolveLocale(r, r.URL.Query().Get("locale"), nil, user)
+	plan, err := h.assistant.GeneratePlan(locale, &data)
+	var validationErr *validator.Error
+	if errors.As(err, &validationErr) {
+		http.Error(w, validator.Message(err, locale), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		log.Printf("Ошибка генерации плана проекта: %v", err)
+		http.Error(w, "Не удалось составить план проекта", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"plan": plan})
+}
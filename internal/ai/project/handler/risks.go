@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+)
+
+// AssessRisks составляет реестр рисков проекта. Принимает данные проекта или контекст мастера;
+// во втором случае реестр сохраняется в контексте и показывается на шаге подтверждения.
+func (h *ProjectAssistantHandler) AssessRisks(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Project *models.ProjectData            `json:"project"`
+		Context *models.ProjectCreationContext `json:"context"`
+		Locale  string                         `json:"locale"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	data := req.Project
+	if req.Context != nil && req.Context.ProjectData != nil {
+		data = req.Context.ProjectData
+	}
+	if data == nil {
+		http.Error(w, "Project data is required", http.StatusBadRequest)
+		return
+	}
+
+	locale := resolveLocale(r, req.Locale, req.Context, user)
+	risks, err := h.assistant.AssessRisks(locale, data)
+	var validationErr *validator.Error
+	if errors.As(err, &validationErr) {
+		http.Error(w, validator.Message(err, locale), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		log.Printf("Ошибка оценки рисков проекта: %v", err)
+		http.Error(w, "Не удалось оценить риски проекта", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{"risks": risks}
+	if req.Context != nil {
+		req.Context.Risks = risks
+		response["context"] = req.Context
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/client"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/service"
+)
+
+// resolveMemberEmails сопоставляет email из сообщения на шаге команды с участниками проектов
+// пользователя: сервис авторизации не ищет пользователей по email. Если сервис проектов
+// недоступен, email остаются ненайденными и ассистент попросит указать ID.
+func (h *ProjectAssistantHandler) resolveMemberEmails(ctx context.Context, r *http.Request, userID, message string, projectContext *models.ProjectCreationContext) {
+	emails := h.assistant.TeamEmails(projectContext, message)
+	if len(emails) == 0 {
+		return
+	}
+	projectContext.KnownMembers = h.findMembers(ctx, r, userID, emails)
+}
+
+// memberLookup возвращает поиск участников проектов пользователя по email для импорта брифа
+func (h *ProjectAssistantHandler) memberLookup(r *http.Request, userID string) service.MemberLookup {
+	return func(emails []string) map[string]string {
+		ctx, cancel := projectServiceContext(r)
+		defer cancel()
+		return h.findMembers(ctx, r, userID, emails)
+	}
+}
+
+func (h *ProjectAssistantHandler) findMembers(ctx context.Context, r *http.Request, userID string, emails []string) map[string]string {
+	credentials := client.Credentials{Authorization: r.Header.Get("Authorization"), UserID: userID}
+	members, err := h.projects.FindMembersByEmail(ctx, credentials, emails)
+	if err != nil {
+		log.Printf("Не удалось найти участников по email для пользователя %s: %v", userID, err)
+	}
+	return members
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/templates"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
+)
+
+// ListTemplates возвращает шаблоны, доступные пользователю
+func (h *ProjectAssistantHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	list, err := h.templates.List(r.Context(), templateOwner(user))
+	if err != nil {
+		log.Printf("Ошибка получения шаблонов проектов: %v", err)
+		http.Error(w, "Не удалось получить шаблоны", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"templates": list})
+}
+
+// GetTemplate возвращает шаблон по идентификатору
+func (h *ProjectAssistantHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	template, err := h.templates.Get(r.Context(), templateOwner(user), chi.URLParam(r, "id"))
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, template)
+}
+
+// CreateTemplate создает личный шаблон пользователя
+func (h *ProjectAssistantHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	template, ok := decodeTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.templates.Create(r.Context(), templateOwner(user), template); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, template)
+}
+
+// UpdateTemplate изменяет шаблон, созданный пользователем
+func (h *ProjectAssistantHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	template, ok := decodeTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.templates.Update(r.Context(), templateOwner(user), chi.URLParam(r, "id"), template); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, template)
+}
+
+// DeleteTemplate удаляет шаблон, созданный пользователем
+func (h *ProjectAssistantHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.templates.Delete(r.Context(), templateOwner(user), chi.URLParam(r, "id")); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// startFromTemplate начинает диалог по шаблону, если он указан в запросе через template_id
+// или сообщением "по шаблону …". Возвращает nil, если шаблон не запрошен.
+func (h *ProjectAssistantHandler) startFromTemplate(ctx context.Context, user *auth_v1.User, templateID, message string, dialog *models.ProjectCreationContext, locale i18n.Locale) (*models.AssistantResponse, error) {
+	name, byName := h.assistant.DetectTemplateRequest(message)
+	if templateID == "" && !byName {
+		return nil, nil
+	}
+	if !h.assistant.CanApplyTemplate(dialog) {
+		return nil, nil
+	}
+
+	owner := templateOwner(user)
+	var template *models.ProjectTemplate
+	var err error
+	if templateID != "" {
+		name = templateID
+		template, err = h.templates.Get(ctx, owner, templateID)
+	} else {
+		template, err = h.templates.FindByName(ctx, owner, name)
+	}
+
+	if errors.Is(err, templates.ErrTemplateNotFound) {
+		return h.assistant.TemplateNotFound(dialog, locale, name), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return h.assistant.StartFromTemplate(template, locale), nil
+}
+
+// templateOwner определяет владельца шаблонов по профилю, полученному при проверке токена.
+// Компанию из профиля не используем: пользователь задает ее сам, и она не подтверждает
+// принадлежность к организации.
+func templateOwner(user *auth_v1.User) templates.Owner {
+	return templates.Owner{UserID: user.GetId()}
+}
+
+func decodeTemplate(w http.ResponseWriter, r *http.Request) (*models.ProjectTemplate, bool) {
+	var template models.ProjectTemplate
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+	if template.Scope == "" {
+		template.Scope = models.TemplateScopeUser
+	}
+
+	if err := validator.ValidateTemplate(&template); err != nil {
+		locale := i18n.Resolve(i18n.FromAcceptLanguage(r.Header.Get("Accept-Language")))
+		http.Error(w, validator.Message(err, locale), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &template, true
+}
+
+func writeTemplateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, templates.ErrTemplateNotFound):
+		http.Error(w, "Шаблон не найден", http.StatusNotFound)
+	case errors.Is(err, templates.ErrTemplateForbidden):
+		http.Error(w, "Шаблон может изменять только его автор", http.StatusForbidden)
+	default:
+		log.Printf("Ошибка работы с шаблоном проекта: %v", err)
+		http.Error(w, "Ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Ошибка кодирования ответа: %v", err)
+	}
+}
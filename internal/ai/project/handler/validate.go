@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/duplicates"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+)
+
+// ValidateProject проверяет данные проекта без запуска диалога. Ошибки возвращаются по путям
+// полей вместе с машиночитаемыми кодами, поэтому фронтенд может подсвечивать поля формы.
+// Проекты пользователя с похожим названием возвращаются в duplicates.
+func (h *ProjectAssistantHandler) ValidateProject(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.VerifyUser(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	userID := user.GetId()
+
+	var data models.ProjectData
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	locale := resolveLocale(r, r.URL.Query().Get("locale"), nil, user)
+	state := validator.ValidateProjectDataIn(locale, &data)
+
+	// Похожие проекты не считаются ошибкой: о них сообщается предупреждением к названию
+	if data.Name != "" {
+		ctx, cancel := projectServiceContext(r)
+		defer cancel()
+		if matches := h.similarProjects(ctx, r, userID, data.Name); len(matches) > 0 {
+			state.Duplicates = matches
+			validator.AddWarning(locale, &state, duplicates.Warning(matches))
+		}
+	}
+	writeJSON(w, http.StatusOK, state)
+}
@@ -0,0 +1,143 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Locale - код языка интерфейса ассистента
+type Locale string
+
+const (
+	RU Locale = "ru"
+	EN Locale = "en"
+
+	// DefaultLocale используется, если язык не удалось определить
+	DefaultLocale = RU
+)
+
+var (
+	mu       sync.RWMutex
+	catalogs = map[Locale]map[string]string{}
+)
+
+// Register добавляет сообщения в каталог языка. Пакеты регистрируют свои сообщения в init().
+func Register(locale Locale, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = make(map[string]string, len(messages))
+		catalogs[locale] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+// Has сообщает, есть ли сообщение с указанным ключом в каталоге языка по умолчанию
+func Has(key string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := catalogs[DefaultLocale][key]
+	return ok
+}
+
+// T возвращает сообщение по ключу на нужном языке. Если перевода нет, используется язык
+// по умолчанию, а если нет и его - сам ключ.
+func T(locale Locale, key string, args ...interface{}) string {
+	mu.RLock()
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	mu.RUnlock()
+
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Normalize приводит значение к поддерживаемому языку или возвращает пустую строку
+func Normalize(tag string) Locale {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	switch Locale(tag) {
+	case RU, EN:
+		return Locale(tag)
+	default:
+		return ""
+	}
+}
+
+// FromAcceptLanguage выбирает поддерживаемый язык с наибольшим весом из заголовка Accept-Language
+func FromAcceptLanguage(header string) Locale {
+	type candidate struct {
+		locale Locale
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := Normalize(fields[0])
+		if locale == "" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if weight > 0 {
+			candidates = append(candidates, candidate{locale: locale, weight: weight})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+	return candidates[0].locale
+}
+
+// FromCountry определяет язык по стране из профиля пользователя
+func FromCountry(country string) Locale {
+	switch strings.ToLower(strings.TrimSpace(country)) {
+	case "":
+		return ""
+	case "ru", "rus", "russia", "россия", "by", "belarus", "беларусь", "kz", "kazakhstan", "казахстан":
+		return RU
+	default:
+		return EN
+	}
+}
+
+// Resolve выбирает первый определенный язык из списка источников в порядке приоритета
+func Resolve(sources ...Locale) Locale {
+	for _, locale := range sources {
+		if locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,155 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Группы ключевых слов, которые распознаются в ответах пользователя
+const (
+	KeywordYes     = "yes"
+	KeywordNo      = "no"
+	KeywordSkip    = "skip"
+	KeywordDone    = "done"
+	KeywordRestart = "restart"
+	KeywordList    = "list"
+	KeywordBack    = "back"
+	KeywordUndo    = "undo"
+	KeywordMore    = "more"
+)
+
+// stem связывает корень слова с каноническим внутренним кодом
+type stem struct {
+	prefix string
+	code   string
+}
+
+var (
+	// exactKeywords - ответы, которые должны совпадать целиком
+	exactKeywords = map[Locale]map[string][]string{
+		RU: {
+			KeywordYes:     {"да", "ага", "конечно", "верно", "ок"},
+			KeywordNo:      {"нет", "неа", "не"},
+			KeywordSkip:    {"пропустить", "пропусти", "пропуск", "-"},
+			KeywordDone:    {"готово", "все", "всё", "хватит"},
+			KeywordRestart: {"заново", "сначала", "начать заново"},
+			KeywordList:    {"список", "команда", "состав"},
+			KeywordBack:    {"назад", "вернись", "вернуться"},
+			KeywordUndo:    {"отмена", "отмени", "отменить"},
+			KeywordMore:    {"ещё", "еще", "ещё варианты", "еще варианты", "другие", "другие варианты", "другой вариант"},
+		},
+		EN: {
+			KeywordYes:     {"yes", "y", "yep", "sure", "ok", "okay", "correct"},
+			KeywordNo:      {"no", "n", "nope"},
+			KeywordSkip:    {"skip", "-"},
+			KeywordDone:    {"done", "finish", "that's all"},
+			KeywordRestart: {"restart", "start over"},
+			KeywordList:    {"list", "team"},
+			KeywordBack:    {"back", "go back"},
+			KeywordUndo:    {"undo", "cancel"},
+			KeywordMore:    {"more", "more options", "other options", "another"},
+		},
+	}
+
+	priorityStems = map[Locale][]stem{
+		// Порядок важен: отрицания "несрочн" и "неважн" нужно проверить раньше, чем "срочн" и "важн"
+		RU: {
+			{"несрочн", "НИЗКИЙ"}, {"не срочн", "НИЗКИЙ"}, {"не очень срочн", "НИЗКИЙ"},
+			{"неважн", "НИЗКИЙ"}, {"не важн", "НИЗКИЙ"}, {"не очень важн", "НИЗКИЙ"},
+			{"низк", "НИЗКИЙ"}, {"потом", "НИЗКИЙ"},
+			{"высок", "ВЫСОКИЙ"}, {"срочн", "ВЫСОКИЙ"}, {"критичн", "ВЫСОКИЙ"}, {"важн", "ВЫСОКИЙ"},
+			{"средн", "СРЕДНИЙ"}, {"нормальн", "СРЕДНИЙ"}, {"обычн", "СРЕДНИЙ"},
+		},
+		EN: {
+			{"not urgent", "НИЗКИЙ"}, {"low", "НИЗКИЙ"},
+			{"high", "ВЫСОКИЙ"}, {"urgent", "ВЫСОКИЙ"}, {"critical", "ВЫСОКИЙ"},
+			{"medium", "СРЕДНИЙ"}, {"normal", "СРЕДНИЙ"}, {"moderate", "СРЕДНИЙ"},
+		},
+	}
+
+	// wholeWordPriority - языки, в которых приоритет ищется только целыми словами: английские
+	// слова не склоняются, а "low" не должен находиться внутри "slow" или "follow"
+	wholeWordPriority = map[Locale]bool{EN: true}
+
+	roleStems = map[Locale][]stem{
+		RU: {
+			{"менеджер", "MANAGER"}, {"руковод", "MANAGER"},
+			{"редакт", "EDITOR"},
+			{"читател", "READER"}, {"наблюдат", "READER"}, {"просмотр", "READER"},
+		},
+		EN: {
+			{"manager", "MANAGER"}, {"owner", "MANAGER"}, {"admin", "MANAGER"},
+			{"editor", "EDITOR"}, {"writer", "EDITOR"},
+			{"viewer", "READER"}, {"reader", "READER"}, {"read-only", "READER"},
+		},
+	}
+)
+
+// IsKeyword сообщает, является ли ответ ключевым словом группы. Сначала проверяется язык
+// пользователя, затем остальные языки, чтобы ответы вроде "ok" или "да" понимались всегда.
+func IsKeyword(locale Locale, group, answer string) bool {
+	answer = strings.Trim(strings.ToLower(strings.TrimSpace(answer)), ".!")
+	for _, l := range searchOrder(locale) {
+		for _, word := range exactKeywords[l][group] {
+			if answer == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NormalizePriority приводит приоритет, записанный словами на любом языке, к каноническому коду
+func NormalizePriority(locale Locale, input string) string {
+	return matchStem(locale, priorityStems, wholeWordPriority, input)
+}
+
+// NormalizeRole приводит роль участника, записанную словами на любом языке, к каноническому коду
+func NormalizeRole(locale Locale, input string) string {
+	return matchStem(locale, roleStems, nil, input)
+}
+
+func matchStem(locale Locale, stems map[Locale][]stem, wholeWord map[Locale]bool, input string) string {
+	input = strings.ToLower(input)
+	for _, l := range searchOrder(locale) {
+		for _, s := range stems[l] {
+			if wholeWord[l] && containsWord(input, s.prefix) || !wholeWord[l] && strings.Contains(input, s.prefix) {
+				return s.code
+			}
+		}
+	}
+	return ""
+}
+
+// containsWord сообщает, встречается ли word в input отдельным словом, а не частью другого слова
+func containsWord(input, word string) bool {
+	for offset := 0; ; {
+		i := strings.Index(input[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(word)
+		before, _ := utf8.DecodeLastRuneInString(input[:start])
+		after, _ := utf8.DecodeRuneInString(input[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// searchOrder возвращает язык пользователя первым, а затем остальные поддерживаемые языки
+func searchOrder(locale Locale) []Locale {
+	order := []Locale{locale}
+	for _, l := range []Locale{RU, EN} {
+		if l != locale {
+			order = append(order, l)
+		}
+	}
+	return order
+}
@@ -0,0 +1,39 @@
+package i18n
+
+import "testing"
+
+func TestNormalizePriority(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		input  string
+		want   string
+	}{
+		{"важно", RU, "это важно", "ВЫСОКИЙ"},
+		{"важный", RU, "Важный проект", "ВЫСОКИЙ"},
+		{"неважно", RU, "неважно", "НИЗКИЙ"},
+		{"не важно", RU, "не важно", "НИЗКИЙ"},
+		{"не очень важный", RU, "не очень важный", "НИЗКИЙ"},
+		{"срочно", RU, "срочно", "ВЫСОКИЙ"},
+		{"несрочно", RU, "несрочно", "НИЗКИЙ"},
+		{"не срочно", RU, "не срочно", "НИЗКИЙ"},
+		{"средний", RU, "средний", "СРЕДНИЙ"},
+		{"low", EN, "low", "НИЗКИЙ"},
+		{"low с регистром", EN, "Low priority", "НИЗКИЙ"},
+		{"slow", EN, "slow", ""},
+		{"follow", EN, "follow up later", ""},
+		{"not urgent", EN, "not urgent", "НИЗКИЙ"},
+		{"high", EN, "high!", "ВЫСОКИЙ"},
+		{"highlight", EN, "highlight", ""},
+		{"английский ответ в русском диалоге", RU, "low", "НИЗКИЙ"},
+		{"пусто", RU, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizePriority(tt.locale, tt.input); got != tt.want {
+				t.Errorf("NormalizePriority(%q, %q) = %q, want %q", tt.locale, tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package importer извлекает текст из брифов проекта в форматах TXT, Markdown и DOCX.
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxBriefSize ограничивает размер загружаемого брифа
+const MaxBriefSize = 5 << 20
+
+var (
+	// ErrUnsupportedFormat возвращается для файлов, из которых не умеем извлекать текст
+	ErrUnsupportedFormat = errors.New("неподдерживаемый формат брифа, допустимы .txt, .md и .docx")
+	// ErrEmptyBrief возвращается, если в брифе нет текста
+	ErrEmptyBrief = errors.New("бриф не содержит текста")
+)
+
+// ExtractText возвращает текст брифа. Формат определяется по расширению файла;
+// файлы без расширения считаются текстовыми.
+func ExtractText(filename string, content []byte) (string, error) {
+	var text string
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case "", ".txt", ".md", ".markdown":
+		if !utf8.Valid(content) {
+			return "", fmt.Errorf("%w: текст должен быть в кодировке UTF-8", ErrUnsupportedFormat)
+		}
+		text = string(content)
+	case ".docx":
+		extracted, err := extractDocx(content)
+		if err != nil {
+			return "", err
+		}
+		text = extracted
+	default:
+		return "", ErrUnsupportedFormat
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrEmptyBrief
+	}
+	return text, nil
+}
+
+// extractDocx читает word/document.xml и собирает текст абзацев. Оформление, таблицы
+// и колонтитулы не сохраняются: для разбора брифа нужен только текст.
+func extractDocx(content []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", fmt.Errorf("%w: файл DOCX поврежден", ErrUnsupportedFormat)
+	}
+
+	for _, file := range archive.File {
+		if file.Name != "word/document.xml" {
+			continue
+		}
+		document, err := file.Open()
+		if err != nil {
+			return "", fmt.Errorf("ошибка чтения DOCX: %w", err)
+		}
+		defer document.Close()
+		return documentText(io.LimitReader(document, MaxBriefSize*4))
+	}
+
+	return "", fmt.Errorf("%w: в файле DOCX нет документа", ErrUnsupportedFormat)
+}
+
+func documentText(document io.Reader) (string, error) {
+	decoder := xml.NewDecoder(document)
+
+	var text strings.Builder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return text.String(), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("ошибка разбора DOCX: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteString("\t")
+			case "br":
+				text.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(element)
+			}
+		}
+	}
+}
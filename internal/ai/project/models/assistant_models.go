@@ -13,9 +13,50 @@ type AssistantRequest struct {
 
 // ProjectCreationContext содержит контекст создания проекта
 type ProjectCreationContext struct {
-	CurrentStep     string          `json:"current_step"`     // Текущий шаг создания проекта
-	ProjectData     *ProjectData    `json:"project_data"`     // Данные проекта
-	ValidationState ValidationState `json:"validation_state"` // Состояние валидации
+	CurrentStep     string          `json:"current_step"`               // Текущий шаг создания проекта
+	ReturnStep      string          `json:"return_step,omitempty"`      // Шаг, на который нужно вернуться после правки поля
+	ProjectData     *ProjectData    `json:"project_data"`               // Данные проекта
+	ValidationState ValidationState `json:"validation_state"`           // Состояние валидации
+	History         []StepSnapshot  `json:"history,omitempty"`          // История пройденных шагов для возврата назад
+	NameCandidates  []string        `json:"name_candidates,omitempty"`  // Сгенерированные варианты названия
+	NameBrief       string          `json:"name_brief,omitempty"`       // Краткое описание проекта для генерации названия, пока описание не заполнено
+	AwaitNameBrief  bool            `json:"await_name_brief,omitempty"` // Ассистент попросил краткое описание, чтобы предложить названия
+	Suggestion      *Suggestion     `json:"suggestion,omitempty"`       // Предложение ассистента, ожидающее ответа пользователя
+	Locale          string          `json:"locale,omitempty"`           // Язык диалога (ru, en)
+	TemplateID      string          `json:"template_id,omitempty"`      // Шаблон, по которому создается проект
+	Prefilled       []string        `json:"prefilled,omitempty"`        // Поля, заполненные из шаблона; их шаги пропускаются
+	SuggestedRoles  TemplateRoles   `json:"suggested_roles,omitempty"`  // Роли команды, рекомендованные шаблоном
+	CreationKey     string          `json:"creation_key,omitempty"`     // Ключ идемпотентности создания проекта
+	SessionID       int64           `json:"session_id,omitempty"`       // Сохраненная на сервере сессия мастера
+	Risks           []Risk          `json:"risks,omitempty"`            // Реестр рисков, составленный ассистентом
+	ProjectID       string          `json:"project_id,omitempty"`       // Редактируемый проект; пустой, если проект создается
+	Original        *ProjectData    `json:"original,omitempty"`         // Данные редактируемого проекта до изменений
+	Duplicates      []ProjectMatch  `json:"duplicates,omitempty"`       // Похожие проекты, о которых нужно спросить пользователя
+	AcceptedName    string          `json:"accepted_name,omitempty"`    // Название, с которым пользователь решил создать проект, несмотря на похожие
+
+	// KnownMembers - идентификаторы участников проектов пользователя по email в нижнем регистре.
+	// Сервис авторизации не ищет пользователей по email, поэтому обработчик заполняет их перед
+	// шагом команды из сервиса проектов. В диалоге не сохраняются.
+	KnownMembers map[string]string `json:"-"`
+}
+
+// IsEdit сообщает, что диалог редактирует существующий проект, а не создает новый
+func (c *ProjectCreationContext) IsEdit() bool {
+	return c.ProjectID != ""
+}
+
+// Suggestion содержит сгенерированное значение поля, которое пользователь еще не принял
+type Suggestion struct {
+	Field   string `json:"field"`   // Поле проекта, для которого сделано предложение
+	Value   string `json:"value"`   // Предлагаемое значение
+	Request string `json:"request"` // Исходный запрос пользователя на генерацию
+}
+
+// StepSnapshot хранит состояние диалога до перехода на следующий шаг
+type StepSnapshot struct {
+	Step        string       `json:"step"`
+	ReturnStep  string       `json:"return_step,omitempty"`
+	ProjectData *ProjectData `json:"project_data"`
 }
 
 // ProjectData содержит данные проекта
@@ -30,6 +71,78 @@ type ProjectData struct {
 	Spent           string       `json:"spent"`
 	Confidentiality string       `json:"confidentiality"`
 	Progress        int          `json:"progress"`
+	EffortDays      float64      `json:"effort_days,omitempty"` // Необязательная оценка трудоемкости в человеко-днях
+	Plan            *ProjectPlan `json:"plan,omitempty"`        // План этапов и задач, предложенный ассистентом
+}
+
+// Clone возвращает независимую копию данных проекта
+func (d *ProjectData) Clone() *ProjectData {
+	if d == nil {
+		return nil
+	}
+	clone := *d
+	if d.Team != nil {
+		clone.Team = make([]TeamMember, len(d.Team))
+		copy(clone.Team, d.Team)
+	}
+	clone.Plan = d.Plan.Clone()
+	return &clone
+}
+
+// Field возвращает значение строкового поля проекта по его JSON-имени
+func (d *ProjectData) Field(field string) (string, bool) {
+	switch field {
+	case "name":
+		return d.Name, true
+	case "description":
+		return d.Description, true
+	case "deadline":
+		return d.Deadline, true
+	case "status":
+		return d.Status, true
+	case "priority":
+		return d.Priority, true
+	case "budget":
+		return d.Budget, true
+	case "spent":
+		return d.Spent, true
+	case "confidentiality":
+		return d.Confidentiality, true
+	default:
+		return "", false
+	}
+}
+
+// SetField устанавливает значение строкового поля проекта по его JSON-имени. Поддерживаются
+// только перечисленные ниже поля: новое поле, заполняемое общим обработчиком шага мастера,
+// нужно добавить сюда и в Field. Команда и план заполняются специальными обработчиками.
+func (d *ProjectData) SetField(field, value string) bool {
+	switch field {
+	case "name":
+		d.Name = value
+	case "description":
+		d.Description = value
+	case "deadline":
+		d.Deadline = value
+	case "status":
+		d.Status = value
+	case "priority":
+		d.Priority = value
+	case "budget":
+		d.Budget = value
+	case "spent":
+		d.Spent = value
+	case "confidentiality":
+		d.Confidentiality = value
+	default:
+		return false
+	}
+	return true
+}
+
+// IsSettableField сообщает, может ли поле быть заполнено через SetField
+func IsSettableField(field string) bool {
+	return new(ProjectData).SetField(field, "")
 }
 
 // TeamMember представляет участника команды
@@ -44,9 +157,12 @@ type TeamMember struct {
 
 // ValidationState содержит состояние валидации данных
 type ValidationState struct {
-	IsValid  bool              `json:"is_valid"`
-	Errors   map[string]string `json:"errors"`
-	Warnings map[string]string `json:"warnings"`
+	IsValid      bool                `json:"is_valid"`
+	Errors       map[string]string   `json:"errors"`                  // Локализованные сообщения об ошибках по пути поля, например "team[0].email"
+	ErrorCodes   map[string]string   `json:"error_codes,omitempty"`   // Машиночитаемые коды ошибок по тем же путям
+	Warnings     map[string]string   `json:"warnings"`                // Предупреждения, которые не мешают созданию проекта
+	WarningCodes map[string][]string `json:"warning_codes,omitempty"` // Коды предупреждений по путям полей
+	Duplicates   []ProjectMatch      `json:"duplicates,omitempty"`    // Существующие проекты с похожим названием
 }
 
 // AssistantResponse представляет ответ от AI-ассистента
@@ -54,5 +170,31 @@ type AssistantResponse struct {
 	Message         string                 `json:"message"`
 	ProjectContext  ProjectCreationContext `json:"project_context"`
 	SuggestedAction string                 `json:"suggested_action"`
+	ProjectID       string                 `json:"project_id,omitempty"`  // Идентификатор созданного проекта
+	CreationID      int64                  `json:"creation_id,omitempty"` // Запись очереди создания проекта для проверки статуса
 	Error           string                 `json:"error,omitempty"`
+	QuickReplies    []Choice               `json:"quick_replies,omitempty"` // Готовые ответы, которые клиент может показать кнопками
+	Step            *StepInfo              `json:"step,omitempty"`          // Текущий шаг мастера для выбора виджета ввода
+	FieldErrors     map[string]string      `json:"field_errors,omitempty"`  // Ошибки ввода по пути поля, например "deadline"
+
+	// Unparsed отмечает ответ шага, который не смог разобрать сообщение. Такое сообщение
+	// дополнительно проверяется классификатором справки.
+	Unparsed bool `json:"-"`
+}
+
+// Choice - вариант ответа. При выборе клиент отправляет Value как сообщение пользователя.
+type Choice struct {
+	Label string `json:"label"` // Текст на языке пользователя
+	Value string `json:"value"` // Сообщение для ассистента или канонический код значения
+}
+
+// StepInfo описывает текущий шаг мастера для клиента
+type StepInfo struct {
+	ID        string   `json:"id"`
+	Field     string   `json:"field,omitempty"`
+	InputType string   `json:"input_type"`         // Тип ввода: text, textarea, date, enum, amount, email, confirm
+	Index     int      `json:"index"`              // Номер шага, начиная с 1; 0 для шагов вне основной последовательности
+	Total     int      `json:"total"`              // Количество шагов в основной последовательности
+	Optional  bool     `json:"optional,omitempty"` // Шаг можно пропустить
+	Options   []Choice `json:"options,omitempty"`  // Допустимые значения поля, например роли участников
 }
@@ -0,0 +1,31 @@
+package models
+
+import "reflect"
+
+// editableFields - поля проекта, которые можно изменить в мастере, в порядке вывода в сводке
+var editableFields = []string{"name", "description", "deadline", "priority", "budget", "spent", "status", "confidentiality"}
+
+// ChangedFields возвращает JSON-имена полей, которые отличаются в current по сравнению с original.
+// Порядок совпадает с порядком полей в сводке проекта; команда и план идут последними.
+func ChangedFields(original, current *ProjectData) []string {
+	if original == nil || current == nil {
+		return nil
+	}
+
+	var changed []string
+	for _, field := range editableFields {
+		before, _ := original.Field(field)
+		after, _ := current.Field(field)
+		if before != after {
+			changed = append(changed, field)
+		}
+	}
+	// Пустая команда и отсутствующая команда считаются одинаковыми
+	if (len(original.Team) > 0 || len(current.Team) > 0) && !reflect.DeepEqual(original.Team, current.Team) {
+		changed = append(changed, "team")
+	}
+	if !reflect.DeepEqual(original.Plan, current.Plan) {
+		changed = append(changed, "plan")
+	}
+	return changed
+}
@@ -0,0 +1,15 @@
+package models
+
+// ProjectSummary - краткие сведения о существующем проекте пользователя
+type ProjectSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProjectMatch - существующий проект с похожим названием
+type ProjectMatch struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Similarity float64 `json:"similarity"` // Сходство названий от 0 до 1
+	Exact      bool    `json:"exact"`      // Названия совпадают после нормализации
+}
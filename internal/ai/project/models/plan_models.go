@@ -0,0 +1,38 @@
+package models
+
+// ProjectPlan содержит разбивку проекта на этапы и задачи. Даты хранятся в формате ДД.ММ.ГГГГ,
+// как и дедлайн проекта.
+type ProjectPlan struct {
+	Milestones []PlanMilestone `json:"milestones"`
+}
+
+// PlanMilestone представляет этап проекта
+type PlanMilestone struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	StartDate   string     `json:"start_date"`
+	DueDate     string     `json:"due_date"`
+	Tasks       []PlanTask `json:"tasks"`
+}
+
+// PlanTask представляет задачу внутри этапа
+type PlanTask struct {
+	Title         string  `json:"title"`
+	EstimateDays  float64 `json:"estimate_days"`  // Оценка трудоемкости в рабочих днях
+	SuggestedRole string  `json:"suggested_role"` // Рекомендуемая роль исполнителя, например "Дизайнер"
+	StartDate     string  `json:"start_date"`
+	DueDate       string  `json:"due_date"`
+}
+
+// Clone возвращает независимую копию плана
+func (p *ProjectPlan) Clone() *ProjectPlan {
+	if p == nil {
+		return nil
+	}
+	clone := &ProjectPlan{Milestones: make([]PlanMilestone, len(p.Milestones))}
+	for i, milestone := range p.Milestones {
+		milestone.Tasks = append([]PlanTask(nil), milestone.Tasks...)
+		clone.Milestones[i] = milestone
+	}
+	return clone
+}
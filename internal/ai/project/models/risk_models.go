@@ -0,0 +1,17 @@
+package models
+
+// Risk представляет запись реестра рисков проекта
+type Risk struct {
+	Category   string `json:"category"`   // Категория: schedule, budget, resources, scope, technical, external
+	Title      string `json:"title"`      // Краткое описание риска
+	Likelihood string `json:"likelihood"` // Вероятность: low, medium, high
+	Impact     string `json:"impact"`     // Влияние: low, medium, high
+	Mitigation string `json:"mitigation"` // Рекомендуемые меры снижения риска
+}
+
+var riskLevelWeights = map[string]int{"low": 1, "medium": 2, "high": 3}
+
+// Score возвращает оценку серьезности риска: произведение вероятности и влияния
+func (r Risk) Score() int {
+	return riskLevelWeights[r.Likelihood] * riskLevelWeights[r.Impact]
+}
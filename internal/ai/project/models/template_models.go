@@ -0,0 +1,91 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Области видимости шаблонов проектов
+const (
+	TemplateScopeSystem = "system" // Встроенный шаблон, доступен всем и не изменяется
+	TemplateScopeUser   = "user"   // Личный шаблон пользователя
+)
+
+// ProjectTemplate описывает шаблон, которым предзаполняется мастер создания проекта
+type ProjectTemplate struct {
+	ID                 string        `json:"id" db:"id"`
+	Scope              string        `json:"scope" db:"scope"`
+	OwnerID            string        `json:"owner_id,omitempty" db:"owner_id"`     // Пользователь, которому принадлежит шаблон
+	CreatedBy          string        `json:"created_by,omitempty" db:"created_by"` // Автор шаблона
+	Name               string        `json:"name" db:"name"`
+	Description        string        `json:"description" db:"description"` // Заготовка описания проекта
+	Priority           string        `json:"priority,omitempty" db:"priority"`
+	DeadlineOffsetDays int           `json:"deadline_offset_days,omitempty" db:"deadline_offset_days"` // Типичный срок проекта в днях от даты создания
+	Confidentiality    string        `json:"confidentiality,omitempty" db:"confidentiality"`
+	TeamRoles          TemplateRoles `json:"team_roles,omitempty" db:"team_roles"`
+	CreatedAt          *time.Time    `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt          *time.Time    `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// TemplateRole описывает роль, которую шаблон рекомендует включить в команду
+type TemplateRole struct {
+	Role  string `json:"role"`            // Роль в проекте: MANAGER, EDITOR или READER
+	Title string `json:"title,omitempty"` // Должность или зона ответственности, например "Дизайнер"
+	Count int    `json:"count,omitempty"` // Рекомендуемое количество участников
+}
+
+// TemplateRoles хранится в базе данных как JSONB
+type TemplateRoles []TemplateRole
+
+// Value реализует driver.Valuer
+func (r TemplateRoles) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan реализует sql.Scanner
+func (r *TemplateRoles) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported team_roles type %T", src)
+	}
+	return json.Unmarshal(data, r)
+}
+
+// Apply заполняет данные проекта значениями шаблона и возвращает список заполненных полей
+func (t *ProjectTemplate) Apply(data *ProjectData, now time.Time) []string {
+	var filled []string
+	if t.Description != "" {
+		data.Description = t.Description
+		filled = append(filled, "description")
+	}
+	if t.DeadlineOffsetDays > 0 {
+		data.Deadline = now.AddDate(0, 0, t.DeadlineOffsetDays).Format("02.01.2006")
+		filled = append(filled, "deadline")
+	}
+	if t.Priority != "" {
+		data.Priority = t.Priority
+		filled = append(filled, "priority")
+	}
+	if t.Confidentiality != "" {
+		data.Confidentiality = t.Confidentiality
+		filled = append(filled, "confidentiality")
+	}
+	return filled
+}
@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/client"
+)
+
+const (
+	// pollInterval - период проверки очереди фоновым обработчиком
+	pollInterval = 10 * time.Second
+	// batchSize ограничивает количество записей, обрабатываемых за один проход
+	batchSize = 20
+)
+
+// Dispatcher доставляет записи очереди в сервис проектов
+type Dispatcher struct {
+	store    *Store
+	projects *client.Client
+}
+
+// NewDispatcher создает обработчик очереди
+func NewDispatcher(store *Store, projects *client.Client) *Dispatcher {
+	return &Dispatcher{store: store, projects: projects}
+}
+
+// CanRunInBackground сообщает, можно ли запускать Run. Фоновые попытки выполняются без токена
+// пользователя, поэтому нужен служебный токен: без него сервис проектов отвечает 401 и запись
+// сразу попадает в dead-letter. Доставка с токеном пользователя через Deliver работает и без него.
+func (d *Dispatcher) CanRunInBackground() bool {
+	return d.projects.HasServiceToken()
+}
+
+// Run периодически доставляет записи очереди, пока не будет отменен ctx.
+// В фоне запрос выполняется со служебным токеном от имени автора проекта.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.dispatchBatch(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchBatch(ctx context.Context) {
+	entries, err := d.store.Claim(ctx, batchSize)
+	if err != nil {
+		log.Printf("Ошибка выборки очереди создания проектов: %v", err)
+		return
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if err := d.Deliver(ctx, entry, client.Credentials{UserID: entry.UserID}); err != nil {
+			log.Printf("Проект из записи очереди %d пока не создан: %v", entry.ID, err)
+		}
+	}
+}
+
+// Deliver выполняет одну попытку создания проекта и сохраняет ее результат в записи.
+// Возвращает ошибку доставки; entry при этом обновляется до актуального состояния.
+// Если ctx истек или отменен, попытка считается временной неудачей и будет повторена в фоне.
+func (d *Dispatcher) Deliver(ctx context.Context, entry *Entry, credentials client.Credentials) error {
+	// Результат попытки сохраняется, даже если время вызывающего уже истекло
+	storeCtx := context.WithoutCancel(ctx)
+
+	project, err := entry.Project()
+	if err != nil {
+		if markErr := d.store.MarkFailed(storeCtx, entry, err, false); markErr != nil {
+			log.Printf("Ошибка сохранения результата доставки %d: %v", entry.ID, markErr)
+		}
+		return err
+	}
+
+	projectID, err := d.projects.CreateProject(ctx, credentials, entry.CreationKey, project)
+	if err != nil {
+		retryable := client.IsRetryable(err) || ctx.Err() != nil
+		if markErr := d.store.MarkFailed(storeCtx, entry, err, retryable); markErr != nil {
+			log.Printf("Ошибка сохранения результата доставки %d: %v", entry.ID, markErr)
+		}
+		if entry.Status == StatusDead {
+			log.Printf("Запись очереди %d перемещена в dead-letter после %d попыток: %v", entry.ID, entry.Attempts, err)
+		}
+		return err
+	}
+
+	if err := d.store.MarkDelivered(storeCtx, entry, projectID); err != nil {
+		log.Printf("Проект %s создан, но запись очереди %d не обновлена: %v", projectID, entry.ID, err)
+		entry.Status = StatusDelivered
+		entry.ProjectID = projectID
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// Статусы записей очереди создания проектов
+const (
+	StatusPending   = "pending"   // Проект еще не создан, доставка будет повторена
+	StatusDelivered = "delivered" // Проект создан в сервисе проектов
+	StatusDead      = "dead"      // Попытки исчерпаны или ошибка не временная
+)
+
+// Статусы сессий мастера в таблице project_conversations
+const (
+	SessionConfirmed = "confirmed" // Пользователь подтвердил создание проекта
+	SessionCreated   = "created"   // Проект создан
+)
+
+const (
+	// MaxAttempts - количество попыток доставки, после которого запись попадает в dead-letter
+	MaxAttempts = 10
+	// leaseDuration - время, на которое запись блокируется для одной попытки доставки.
+	// Сроки блокировок и повторов вычисляются в базе данных, чтобы не зависеть от часового пояса сервиса.
+	leaseDuration  = time.Minute
+	baseRetryDelay = 5 * time.Second
+	maxRetryDelay  = 30 * time.Minute
+)
+
+var ErrNotFound = errors.New("project creation not found")
+
+// Entry - запись очереди создания проекта
+type Entry struct {
+	ID            int64     `json:"id" db:"id"`
+	SessionID     int64     `json:"session_id" db:"session_id"`
+	UserID        string    `json:"-" db:"user_id"`
+	CreationKey   string    `json:"-" db:"creation_key"`
+	Payload       []byte    `json:"-" db:"payload"`
+	Status        string    `json:"status" db:"status"`
+	Attempts      int       `json:"attempts" db:"attempts"`
+	ProjectID     string    `json:"project_id,omitempty" db:"project_id"`
+	LastError     string    `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Project возвращает данные проекта, сохраненные в записи
+func (e *Entry) Project() (*models.ProjectData, error) {
+	var data models.ProjectData
+	if err := json.Unmarshal(e.Payload, &data); err != nil {
+		return nil, fmt.Errorf("ошибка разбора данных проекта: %w", err)
+	}
+	return &data, nil
+}
+
+// Store хранит очередь создания проектов в базе данных
+type Store struct {
+	db *sqlx.DB
+}
+
+func NewStore(db *sqlx.DB) *Store {
+	return &Store{db: db}
+}
+
+const entryColumns = `id, session_id, user_id, creation_key, payload, status, attempts, project_id,
+        last_error, next_attempt_at, created_at, updated_at`
+
+// Enqueue в одной транзакции сохраняет сессию мастера как подтвержденную и добавляет проект
+// в очередь создания. Новая запись сразу блокируется для первой попытки доставки в рамках запроса.
+// Повторный вызов с тем же ключом создания возвращает существующую запись и блокирует ее,
+// только если она ожидает доставки и ее не обрабатывает фоновый обработчик. claimed сообщает,
+// что запись заблокирована для вызывающего и ее можно доставлять.
+func (s *Store) Enqueue(ctx context.Context, userID string, dialog *models.ProjectCreationContext) (entry *Entry, claimed bool, err error) {
+	payload, err := json.Marshal(dialog.ProjectData)
+	if err != nil {
+		return nil, false, fmt.Errorf("ошибка маршалинга данных проекта: %w", err)
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	sessionID, err := saveConfirmedSession(ctx, tx, userID, dialog)
+	if err != nil {
+		return nil, false, fmt.Errorf("ошибка сохранения сессии мастера: %w", err)
+	}
+
+	entry = &Entry{}
+	err = tx.GetContext(ctx, entry, `
+        INSERT INTO project_creation_outbox (session_id, user_id, creation_key, payload, locked_until)
+        VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP + $5 * INTERVAL '1 second')
+        ON CONFLICT (creation_key) DO NOTHING
+        RETURNING `+entryColumns,
+		sessionID, userID, dialog.CreationKey, payload, leaseDuration.Seconds())
+	claimed = err == nil
+	if errors.Is(err, sql.ErrNoRows) {
+		// Запись уже есть: блокируем ее, чтобы не доставлять одновременно с фоновым обработчиком
+		err = tx.GetContext(ctx, entry, `
+            UPDATE project_creation_outbox
+            SET locked_until = CURRENT_TIMESTAMP + $3 * INTERVAL '1 second'
+            WHERE creation_key = $1 AND user_id = $2 AND status = $4
+              AND (locked_until IS NULL OR locked_until < CURRENT_TIMESTAMP)
+            RETURNING `+entryColumns, dialog.CreationKey, userID, leaseDuration.Seconds(), StatusPending)
+		claimed = err == nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		err = tx.GetContext(ctx, entry, `
+            SELECT `+entryColumns+`
+            FROM project_creation_outbox
+            WHERE creation_key = $1 AND user_id = $2`, dialog.CreationKey, userID)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("ошибка добавления проекта в очередь: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return entry, claimed, nil
+}
+
+// saveConfirmedSession сохраняет контекст мастера со статусом confirmed и возвращает идентификатор сессии
+func saveConfirmedSession(ctx context.Context, tx *sqlx.Tx, userID string, dialog *models.ProjectCreationContext) (int64, error) {
+	if dialog.SessionID != 0 {
+		contextJSON, err := json.Marshal(dialog)
+		if err != nil {
+			return 0, err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+            UPDATE project_conversations
+            SET context = $3, status = $4, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1 AND user_id = $2`, dialog.SessionID, userID, contextJSON, SessionConfirmed)
+		if err != nil {
+			return 0, err
+		}
+		if rows, _ := result.RowsAffected(); rows > 0 {
+			return dialog.SessionID, nil
+		}
+	}
+
+	// Идентификатор сессии сохраняется в контексте, поэтому сначала создаем запись, а затем
+	// записываем в нее контекст с уже известным идентификатором
+	var sessionID int64
+	if err := tx.GetContext(ctx, &sessionID, `
+        INSERT INTO project_conversations (user_id, context, status)
+        VALUES ($1, '{}', $2)
+        RETURNING id`, userID, SessionConfirmed); err != nil {
+		return 0, err
+	}
+	dialog.SessionID = sessionID
+
+	contextJSON, err := json.Marshal(dialog)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE project_conversations SET context = $2 WHERE id = $1`, sessionID, contextJSON)
+	return sessionID, err
+}
+
+// Get возвращает запись очереди, принадлежащую пользователю
+func (s *Store) Get(ctx context.Context, userID string, id int64) (*Entry, error) {
+	var entry Entry
+	err := s.db.GetContext(ctx, &entry, `
+        SELECT `+entryColumns+`
+        FROM project_creation_outbox
+        WHERE id = $1 AND user_id = $2`, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Claim блокирует до limit записей, которые пора доставить. Блокировка снимается после
+// попытки доставки или по истечении leaseDuration, если обработчик завершился аварийно.
+func (s *Store) Claim(ctx context.Context, limit int) ([]Entry, error) {
+	var entries []Entry
+	err := s.db.SelectContext(ctx, &entries, `
+        UPDATE project_creation_outbox
+        SET locked_until = CURRENT_TIMESTAMP + $1 * INTERVAL '1 second'
+        WHERE id IN (
+            SELECT id FROM project_creation_outbox
+            WHERE status = $2
+              AND next_attempt_at <= CURRENT_TIMESTAMP
+              AND (locked_until IS NULL OR locked_until < CURRENT_TIMESTAMP)
+            ORDER BY next_attempt_at
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING `+entryColumns, leaseDuration.Seconds(), StatusPending, limit)
+	return entries, err
+}
+
+// MarkDelivered отмечает проект созданным вместе с сессией мастера
+func (s *Store) MarkDelivered(ctx context.Context, entry *Entry, projectID string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.GetContext(ctx, entry, `
+        UPDATE project_creation_outbox
+        SET status = $2, project_id = $3, attempts = attempts + 1, last_error = '',
+            locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+        RETURNING `+entryColumns, entry.ID, StatusDelivered, projectID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE project_conversations
+        SET status = $2, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1`, entry.SessionID, SessionCreated); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MarkFailed сохраняет ошибку доставки и планирует следующую попытку. Если ошибка не временная
+// или попытки исчерпаны, запись переводится в dead-letter.
+func (s *Store) MarkFailed(ctx context.Context, entry *Entry, cause error, retryable bool) error {
+	attempts := entry.Attempts + 1
+	status := StatusPending
+	if !retryable || attempts >= MaxAttempts {
+		status = StatusDead
+	}
+
+	return s.db.GetContext(ctx, entry, `
+        UPDATE project_creation_outbox
+        SET status = $2, attempts = $3, last_error = $4,
+            next_attempt_at = CURRENT_TIMESTAMP + $5 * INTERVAL '1 second',
+            locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+        RETURNING `+entryColumns, entry.ID, status, attempts, cause.Error(), retryDelay(attempts).Seconds())
+}
+
+// retryDelay возвращает экспоненциальную задержку перед следующей попыткой
+func retryDelay(attempts int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -2,8 +2,14 @@ package prompts
 
 import (
 	"fmt"
-	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
 )
 
 const (
@@ -22,7 +28,10 @@ const (
 2. Описание (10-3000 символов)
 3. Дедлайн (в формате ДД.ММ.ГГГГ)
 4. Приоритет (ВЫСОКИЙ/СРЕДНИЙ/НИЗКИЙ)
-5. Команда проекта (опционально)
+5. Бюджет (опционально)
+6. Статус (опционально)
+7. Конфиденциальность (опционально)
+8. Команда проекта (опционально)
 
 Ты всегда должен проверять все данные на соответствие требованиям.`
 
@@ -32,7 +41,7 @@ const (
 
 Требования к названию:
 • От 3 до 100 символов
-• Может содержать буквы, цифры, пробелы и символы - _
+• Может содержать буквы, цифры, пробелы и символы - _ . , & ( )
 • Должно быть информативным
 
 Напишите название или попросите помощь в генерации названия.`
@@ -62,6 +71,33 @@ const (
 
 Напишите "высокий", "средний" или "низкий".`
 
+	BudgetPrompt = `Укажите бюджет проекта (необязательно).
+
+Можно писать цифрами или словами, например:
+• 150000 руб
+• 1.5M EUR
+• два миллиона рублей
+
+Напишите "пропустить", если бюджет пока не определен.`
+
+	SpentPrompt = `Укажите сумму, уже потраченную на проект, например: 50000 руб.`
+
+	StatusPrompt = `Выберите статус проекта (необязательно):
+
+1. 🗓 Запланирован
+2. 🚀 В процессе
+3. ⏸ Приостановлен
+
+Напишите номер или название статуса, либо "пропустить", чтобы оставить "В процессе".`
+
+	ConfidentialityPrompt = `Кто может видеть проект? (необязательно)
+
+1. 🔒 Только для участников
+2. 🏢 Вся организация
+3. 🌍 Публичный
+
+Напишите номер варианта или "пропустить", чтобы оставить "Только для участников".`
+
 	TeamPrompt = `Теперь давайте добавим команду проекта.
 
 Доступные роли:
@@ -69,7 +105,12 @@ const (
 ✏️ Редактор - может редактировать задачи
 👀 Читатель - может только просматривать
 
-Введите email участника или напишите "готово", если хотите пропустить этот шаг.`
+Введите email или ID участника и его роль, например: "ivan@company.ru редактор".
+По email находятся коллеги, которые уже участвуют в ваших проектах, остальных укажите по ID.
+Можно добавить несколько участников через запятую, удалить участника командой "удали <email или ID>"
+или посмотреть текущий состав командой "список".
+
+Напишите "готово", когда команда будет собрана, или чтобы пропустить этот шаг.`
 
 	GenerateDescriptionPrompt = `Помогу составить описание проекта. Расскажите кратко, о чём ваш проект, и я помогу составить подробное описание.`
 
@@ -77,12 +118,36 @@ const (
 
 %s
 
-Всё верно? Ответьте "да" для создания проекта или "нет" для внесения изменений.`
-)
+Всё верно? Ответьте "да" для создания проекта или "нет" для внесения изменений.
 
-// GetProjectDataSummary форматирует данные проекта для подтверждения
-func GetProjectDataSummary(data *models.ProjectData) string {
-	return fmt.Sprintf(`
+Можно сразу исправить отдельное поле, например: "поменяй дедлайн на 01.06.2027" или "измени приоритет".
+Чтобы разбить проект на этапы и задачи, напишите "составь план", а чтобы оценить риски - "оцени риски".`
+
+	EditConfirmationPrompt = `Изменения в проекте:
+
+%s
+
+Сохранить изменения? Ответьте "да", чтобы сохранить, или "нет", чтобы продолжить редактирование.
+Можно сразу исправить отдельное поле, например: "поменяй дедлайн на 01.06.2027" или "измени приоритет".`
+
+	NamePrompt = `Введите новое название проекта.
+
+Требования к названию:
+• От 3 до 100 символов
+• Может содержать буквы, цифры, пробелы и символы - _ . , & ( )`
+
+	EditChoicePrompt = `Что нужно изменить? Напишите, например:
+• "измени название"
+• "поменяй описание"
+• "поменяй дедлайн на 01.06.2027"
+• "измени приоритет на высокий"
+• "поменяй бюджет на 2 млн рублей"
+• "измени статус" или "измени конфиденциальность"
+• "измени команду"
+
+Или напишите "заново", чтобы начать создание проекта с начала.`
+
+	SummaryTemplate = `
 📋 Название: %s
 
 📝 Описание: 
@@ -92,41 +157,302 @@ func GetProjectDataSummary(data *models.ProjectData) string {
 
 ⚡ Приоритет: %s
 
+💰 Бюджет: %s
+
+📊 Статус: %s
+
+🔒 Конфиденциальность: %s
+
 👥 Команда: %s
-`,
+`
+)
+
+func init() {
+	i18n.Register(i18n.RU, map[string]string{
+		"prompt.welcome":              WelcomeMessage,
+		"prompt.name":                 NamePrompt,
+		"prompt.description":          DescriptionPrompt,
+		"prompt.deadline":             DeadlinePrompt,
+		"prompt.priority":             PriorityPrompt,
+		"prompt.budget":               BudgetPrompt,
+		"prompt.spent":                SpentPrompt,
+		"prompt.status":               StatusPrompt,
+		"prompt.confidentiality":      ConfidentialityPrompt,
+		"prompt.team":                 TeamPrompt,
+		"prompt.generate_description": GenerateDescriptionPrompt,
+		"prompt.confirmation":         ConfirmationPrompt,
+		"prompt.edit_confirmation":    EditConfirmationPrompt,
+		"prompt.edit_choice":          EditChoicePrompt,
+		"prompt.summary":              SummaryTemplate,
+		"summary.not_specified":       "Не указан",
+		"summary.no_members":          "Не добавлено ни одного участника",
+		"summary.member":              "\n• %s %s (%s)\n  Роль: %s",
+		"summary.template_role":       "• %s — %s",
+		"summary.template_role_count": "• %s — %s, %d чел.",
+		"summary.plan":                "\n🗺 План проекта:\n%s",
+		"summary.plan_milestone":      "\n%d. %s (%s — %s)",
+		"summary.plan_task":           "\n   • %s — %s, %s (%s — %s)",
+		"summary.plan_estimate":       "%s дн.",
+		"summary.risks":               "⚠️ Основные риски:\n%s",
+		"summary.risk":                "• [%s] %s — вероятность %s, влияние %s.\n  Меры: %s",
+		"summary.change":              "• %s: %s → %s",
+		"summary.change_team":         "• %s:\n  было: %s\n  стало: %s",
+		"summary.no_plan":             "без плана",
+		"summary.plan_brief":          "этапов: %d, задач: %d",
+
+		"field.name":            "название",
+		"field.description":     "описание",
+		"field.deadline":        "дедлайн",
+		"field.priority":        "приоритет",
+		"field.budget":          "бюджет",
+		"field.spent":           "потрачено",
+		"field.status":          "статус",
+		"field.confidentiality": "конфиденциальность",
+		"field.team":            "команда",
+		"field.plan":            "план",
+	})
+}
+
+// Get возвращает текст подсказки на указанном языке
+func Get(locale i18n.Locale, key string) string {
+	return i18n.T(locale, "prompt."+key)
+}
+
+// stepPromptKeys - подсказки шагов, на которые может ссылаться prompt_key в описании мастера.
+// Подсказка подтверждения собирается отдельно со сводкой данных проекта.
+var stepPromptKeys = map[string]bool{
+	"name": true, "description": true, "deadline": true, "priority": true, "budget": true,
+	"spent": true, "status": true, "confidentiality": true, "team": true,
+}
+
+// HasStepPrompt сообщает, есть ли в каталоге подсказка шага с указанным ключом
+func HasStepPrompt(key string) bool {
+	return stepPromptKeys[key] || key == "confirmation"
+}
+
+// GetStepPrompt возвращает подсказку для повторного заполнения поля
+func GetStepPrompt(locale i18n.Locale, step string) string {
+	if !stepPromptKeys[step] {
+		return ""
+	}
+	return Get(locale, step)
+}
+
+// GetConfirmationPrompt возвращает запрос подтверждения со сводкой данных проекта
+func GetConfirmationPrompt(locale i18n.Locale, data *models.ProjectData) string {
+	return fmt.Sprintf(Get(locale, "confirmation"), GetProjectDataSummary(locale, data))
+}
+
+// GetProjectDataSummary форматирует данные проекта для подтверждения
+func GetProjectDataSummary(locale i18n.Locale, data *models.ProjectData) string {
+	summary := fmt.Sprintf(Get(locale, "summary"),
 		data.Name,
 		data.Description,
 		data.Deadline,
-		data.Priority,
-		formatTeamSummary(data.Team),
+		FormatPriority(locale, data.Priority),
+		formatAmount(locale, data.Budget),
+		FormatStatus(locale, data.Status),
+		FormatConfidentiality(locale, data.Confidentiality),
+		formatTeamSummary(locale, data.Team),
 	)
+	if data.Plan != nil {
+		summary += i18n.T(locale, "summary.plan", FormatPlan(locale, data.Plan))
+	}
+	return summary
+}
+
+// GetEditConfirmationPrompt возвращает запрос подтверждения изменений существующего проекта
+func GetEditConfirmationPrompt(locale i18n.Locale, original, data *models.ProjectData, fields []string) string {
+	return fmt.Sprintf(Get(locale, "edit_confirmation"), FormatChanges(locale, original, data, fields))
 }
 
-func formatTeamSummary(team []models.TeamMember) string {
+// FormatChanges построчно форматирует изменения полей проекта: прежнее и новое значение
+func FormatChanges(locale i18n.Locale, original, data *models.ProjectData, fields []string) string {
+	lines := make([]string, 0, len(fields))
+	for _, field := range fields {
+		label := capitalize(FieldLabel(locale, field))
+		switch field {
+		case "team":
+			lines = append(lines, i18n.T(locale, "summary.change_team", label,
+				formatTeamMembers(locale, original.Team), formatTeamMembers(locale, data.Team)))
+		case "plan":
+			lines = append(lines, i18n.T(locale, "summary.change", label,
+				formatPlanBrief(locale, original.Plan), formatPlanBrief(locale, data.Plan)))
+		default:
+			before, _ := original.Field(field)
+			after, _ := data.Field(field)
+			lines = append(lines, i18n.T(locale, "summary.change", label,
+				formatFieldValue(locale, field, before), formatFieldValue(locale, field, after)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// capitalize делает первую букву строки заглавной
+func capitalize(text string) string {
+	r, size := utf8.DecodeRuneInString(text)
+	if size == 0 {
+		return text
+	}
+	return string(unicode.ToUpper(r)) + text[size:]
+}
+
+// formatFieldValue возвращает значение поля в том виде, в каком оно выводится в сводке
+func formatFieldValue(locale i18n.Locale, field, value string) string {
+	switch field {
+	case "priority":
+		return FormatPriority(locale, value)
+	case "status":
+		return FormatStatus(locale, value)
+	case "confidentiality":
+		return FormatConfidentiality(locale, value)
+	case "budget", "spent":
+		return formatAmount(locale, value)
+	}
+	if value == "" {
+		return i18n.T(locale, "summary.not_specified")
+	}
+	return value
+}
+
+// formatTeamMembers кратко перечисляет участников в одну строку
+func formatTeamMembers(locale i18n.Locale, team []models.TeamMember) string {
 	if len(team) == 0 {
-		return "Не добавлено ни одного участника"
+		return i18n.T(locale, "summary.no_members")
+	}
+	members := make([]string, 0, len(team))
+	for _, member := range team {
+		members = append(members, fmt.Sprintf("%s %s (%s)", member.Name, member.Lastname, FormatRole(locale, member.Role)))
+	}
+	return strings.Join(members, ", ")
+}
+
+// formatPlanBrief кратко описывает план: количество этапов и задач
+func formatPlanBrief(locale i18n.Locale, plan *models.ProjectPlan) string {
+	if plan == nil {
+		return i18n.T(locale, "summary.no_plan")
+	}
+	tasks := 0
+	for _, milestone := range plan.Milestones {
+		tasks += len(milestone.Tasks)
+	}
+	return i18n.T(locale, "summary.plan_brief", len(plan.Milestones), tasks)
+}
+
+// FormatPlan форматирует этапы и задачи плана проекта
+func FormatPlan(locale i18n.Locale, plan *models.ProjectPlan) string {
+	var summary strings.Builder
+	for i, milestone := range plan.Milestones {
+		summary.WriteString(i18n.T(locale, "summary.plan_milestone", i+1, milestone.Title, milestone.StartDate, milestone.DueDate))
+		for _, task := range milestone.Tasks {
+			estimate := i18n.T(locale, "summary.plan_estimate", strconv.FormatFloat(task.EstimateDays, 'f', -1, 64))
+			summary.WriteString(i18n.T(locale, "summary.plan_task", task.Title, estimate, task.SuggestedRole, task.StartDate, task.DueDate))
+		}
+	}
+	return summary.String()
+}
+
+func formatAmount(locale i18n.Locale, amount string) string {
+	if amount == "" || amount == "0" {
+		return i18n.T(locale, "summary.not_specified")
+	}
+	return amount
+}
+
+// GetTeamSummary форматирует текущий состав команды
+func GetTeamSummary(locale i18n.Locale, team []models.TeamMember) string {
+	return formatTeamSummary(locale, team)
+}
+
+func formatTeamSummary(locale i18n.Locale, team []models.TeamMember) string {
+	if len(team) == 0 {
+		return i18n.T(locale, "summary.no_members")
 	}
 
 	var summary strings.Builder
 	for _, member := range team {
-		summary.WriteString(fmt.Sprintf("\n• %s %s (%s)\n  Роль: %s",
+		summary.WriteString(i18n.T(locale, "summary.member",
 			member.Name,
 			member.Lastname,
 			member.Email,
-			formatRole(member.Role)))
+			FormatRole(locale, member.Role)))
 	}
 	return summary.String()
 }
 
-func formatRole(role string) string {
-	switch role {
-	case "MANAGER":
-		return "Менеджер"
-	case "EDITOR":
-		return "Редактор"
-	case "READER":
-		return "Читатель"
-	default:
-		return role
+// FieldLabel возвращает название поля проекта на языке пользователя
+func FieldLabel(locale i18n.Locale, field string) string {
+	key := "field." + field
+	if label := i18n.T(locale, key); label != key {
+		return label
+	}
+	return field
+}
+
+// FormatTemplateRoles форматирует роли команды, рекомендованные шаблоном
+func FormatTemplateRoles(locale i18n.Locale, roles models.TemplateRoles) string {
+	lines := make([]string, 0, len(roles))
+	for _, role := range roles {
+		title := role.Title
+		if title == "" {
+			title = FormatRole(locale, role.Role)
+		}
+		if role.Count > 1 {
+			lines = append(lines, i18n.T(locale, "summary.template_role_count", title, FormatRole(locale, role.Role), role.Count))
+		} else {
+			lines = append(lines, i18n.T(locale, "summary.template_role", title, FormatRole(locale, role.Role)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatTopRisks форматирует самые серьезные риски из реестра, не более limit штук
+func FormatTopRisks(locale i18n.Locale, risks []models.Risk, limit int) string {
+	top := append([]models.Risk(nil), risks...)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Score() > top[j].Score()
+	})
+	if len(top) > limit {
+		top = top[:limit]
+	}
+
+	lines := make([]string, 0, len(top))
+	for _, risk := range top {
+		lines = append(lines, i18n.T(locale, "summary.risk",
+			formatEnum(locale, "risk_category", risk.Category),
+			risk.Title,
+			formatEnum(locale, "risk_level", risk.Likelihood),
+			formatEnum(locale, "risk_level", risk.Impact),
+			risk.Mitigation))
+	}
+	return i18n.T(locale, "summary.risks", strings.Join(lines, "\n"))
+}
+
+// FormatPriority возвращает название приоритета на языке пользователя
+func FormatPriority(locale i18n.Locale, priority string) string {
+	return formatEnum(locale, "priority", priority)
+}
+
+// FormatStatus возвращает название статуса на языке пользователя
+func FormatStatus(locale i18n.Locale, status string) string {
+	return formatEnum(locale, "status", status)
+}
+
+// FormatConfidentiality возвращает название уровня конфиденциальности на языке пользователя
+func FormatConfidentiality(locale i18n.Locale, level string) string {
+	return formatEnum(locale, "confidentiality", level)
+}
+
+// FormatRole возвращает название роли участника на языке пользователя
+func FormatRole(locale i18n.Locale, role string) string {
+	return formatEnum(locale, "role", role)
+}
+
+// formatEnum ищет отображаемое название канонического кода; неизвестные коды выводятся как есть
+func formatEnum(locale i18n.Locale, kind, code string) string {
+	key := "enum." + kind + "." + code
+	if name := i18n.T(locale, key); name != key {
+		return name
 	}
+	return code
 }
@@ -0,0 +1,165 @@
+package prompts
+
+import "github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+
+func init() {
+	i18n.Register(i18n.EN, map[string]string{
+		"prompt.welcome": `Hello! I will help you create a new project.
+
+Let's start with the project name. What would you like to call it?
+
+Name requirements:
+• 3 to 100 characters
+• Letters, digits, spaces and the characters - _ . , & ( )
+• Should be informative
+
+Type a name or ask me to suggest one.`,
+
+		"prompt.name": `Enter a new project name.
+
+Name requirements:
+• 3 to 100 characters
+• Letters, digits, spaces and the characters - _ . , & ( )`,
+
+		"prompt.description": `Great name! Now let's add a project description.
+
+Description requirements:
+• 10 to 3000 characters
+• Should be informative
+• Describe the main goals and objectives
+• Use complete sentences
+
+Type a description or ask me to generate one.`,
+
+		"prompt.deadline": `Good! Now set the project deadline.
+
+Date requirements:
+• Format: DD.MM.YYYY
+• Example: 31.12.2024
+• The date must be in the future`,
+
+		"prompt.priority": `Thank you! Choose the project priority:
+
+🔴 HIGH - urgent and business-critical projects
+🟡 MEDIUM - important projects without special urgency
+🟢 LOW - projects that can wait
+
+Type "high", "medium" or "low".`,
+
+		"prompt.budget": `Set the project budget (optional).
+
+You can use digits, for example:
+• 150000 USD
+• 1.5M EUR
+• 200k
+
+Type "skip" if the budget is not defined yet.`,
+
+		"prompt.spent": `Enter the amount already spent on the project, for example: 50000 USD.`,
+
+		"prompt.status": `Choose the project status (optional):
+
+1. 🗓 Planned
+2. 🚀 In progress
+3. ⏸ On hold
+
+Type the number or the status name, or "skip" to keep "In progress".`,
+
+		"prompt.confidentiality": `Who can see the project? (optional)
+
+1. 🔒 Members only
+2. 🏢 Whole organization
+3. 🌍 Public
+
+Type the option number or "skip" to keep "Members only".`,
+
+		"prompt.team": `Now let's add the project team.
+
+Available roles:
+👑 Manager - full access to the project
+✏️ Editor - can edit tasks
+👀 Viewer - read-only access
+
+Enter a member's email or ID and role, for example: "john@company.com editor".
+Emails work for colleagues who already take part in your projects, use the ID for everyone else.
+You can add several members separated by commas, remove a member with "remove <email or ID>"
+or show the current team with "list".
+
+Type "done" when the team is complete or to skip this step.`,
+
+		"prompt.generate_description": `I'll help you write the project description. Tell me briefly what your project is about and I'll draft a detailed description.`,
+
+		"prompt.confirmation": `Let's review the details:
+
+%s
+
+Is everything correct? Answer "yes" to create the project or "no" to make changes.
+
+You can also change a single field right away, for example: "change deadline to 01.06.2027" or "change priority".
+To break the project down into milestones and tasks, type "make a plan"; to review the risks, type "assess risks".`,
+
+		"prompt.edit_confirmation": `Changes to the project:
+
+%s
+
+Save the changes? Answer "yes" to save or "no" to keep editing.
+You can also change a single field right away, for example: "change deadline to 01.06.2027" or "change priority".`,
+
+		"prompt.edit_choice": `What should be changed? For example:
+• "change name"
+• "change description"
+• "change deadline to 01.06.2027"
+• "change priority to high"
+• "change budget to 2M USD"
+• "change status" or "change confidentiality"
+• "change team"
+
+Or type "restart" to start creating the project from scratch.`,
+
+		"prompt.summary": `
+📋 Name: %s
+
+📝 Description: 
+%s
+
+📅 Deadline: %s
+
+⚡ Priority: %s
+
+💰 Budget: %s
+
+📊 Status: %s
+
+🔒 Confidentiality: %s
+
+👥 Team: %s
+`,
+
+		"summary.not_specified":       "Not specified",
+		"summary.no_members":          "No members added",
+		"summary.member":              "\n• %s %s (%s)\n  Role: %s",
+		"summary.template_role":       "• %s — %s",
+		"summary.template_role_count": "• %s — %s, %d people",
+		"summary.plan":                "\n🗺 Project plan:\n%s",
+		"summary.plan_milestone":      "\n%d. %s (%s — %s)",
+		"summary.plan_task":           "\n   • %s — %s, %s (%s — %s)",
+		"summary.plan_estimate":       "%s d",
+		"summary.risks":               "⚠️ Top risks:\n%s",
+		"summary.risk":                "• [%s] %s — likelihood %s, impact %s.\n  Mitigation: %s",
+		"summary.change":              "• %s: %s → %s",
+		"summary.change_team":         "• %s:\n  before: %s\n  after: %s",
+		"summary.no_plan":             "no plan",
+		"summary.plan_brief":          "milestones: %d, tasks: %d",
+
+		"field.name":            "name",
+		"field.description":     "description",
+		"field.deadline":        "deadline",
+		"field.priority":        "priority",
+		"field.budget":          "budget",
+		"field.spent":           "spent",
+		"field.status":          "status",
+		"field.confidentiality": "confidentiality",
+		"field.team":            "team",
+		"field.plan":            "plan",
+	})
+}
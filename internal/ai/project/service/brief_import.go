@@ -0,0 +1,255 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/prompts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+// maxBriefRunes ограничивает объем брифа, который передается модели
+const maxBriefRunes = 20000
+
+const briefSystemPrompt = `Ты - помощник менеджера проектов. Извлеки из брифа данные проекта.
+Правила:
+- заполняй только то, что явно есть в брифе; если данных нет, оставь пустую строку или пустой список
+- description - подробное описание целей, задач и ожидаемых результатов проекта, не длиннее %d символов
+- deadline - дата в формате ДД.ММ.ГГГГ; относительные сроки считай от сегодняшней даты
+- budget и spent - сумма и валюта, например "150000 RUB"
+- priority, status, confidentiality и роли участников выбирай только из допустимых значений`
+
+// briefSchema описывает ответ модели для структурированного вывода Mistral
+var briefSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"name":            map[string]interface{}{"type": "string"},
+		"description":     map[string]interface{}{"type": "string"},
+		"deadline":        map[string]interface{}{"type": "string"},
+		"priority":        map[string]interface{}{"type": "string", "enum": append([]string{""}, validator.Priorities...)},
+		"budget":          map[string]interface{}{"type": "string"},
+		"spent":           map[string]interface{}{"type": "string"},
+		"status":          map[string]interface{}{"type": "string", "enum": append([]string{""}, validator.ProjectStatuses...)},
+		"confidentiality": map[string]interface{}{"type": "string", "enum": append([]string{""}, validator.ConfidentialityLevels...)},
+		"team": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"email":    map[string]interface{}{"type": "string"},
+					"name":     map[string]interface{}{"type": "string"},
+					"lastname": map[string]interface{}{"type": "string"},
+					"role":     map[string]interface{}{"type": "string", "enum": validator.Roles},
+				},
+				"required":             []string{"email", "name", "lastname", "role"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"name", "description", "deadline", "priority", "budget", "spent", "status", "confidentiality", "team"},
+	"additionalProperties": false,
+}
+
+// MemberLookup сопоставляет email с идентификаторами пользователей. Ключи результата - email
+// в нижнем регистре; ненайденных email в нем нет.
+type MemberLookup func(emails []string) map[string]string
+
+// importIssue описывает поле брифа, которое не удалось принять
+type importIssue struct {
+	field   string
+	message string
+}
+
+// ImportBrief извлекает данные проекта из брифа и открывает сессию мастера на первом
+// незаполненном или некорректном поле. Корректно извлеченные поля считаются предзаполненными,
+// и мастер их пропускает. Участники из брифа ищутся через lookup так же, как на шаге команды.
+func (pa *ProjectAssistant) ImportBrief(brief string, locale i18n.Locale, lookup MemberLookup) (*models.AssistantResponse, error) {
+	context := pa.newContext(locale)
+
+	extracted, err := pa.extractBrief(context, brief)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := pa.applyBrief(context, extracted)
+	issues = append(issues, pa.applyBriefTeam(context, extracted.Team, lookup)...)
+	context.ValidationState = validator.ValidateProjectDataIn(localeOf(context), context.ProjectData)
+
+	var message strings.Builder
+	message.WriteString(t(context, "import.done"))
+	if len(context.Prefilled) > 0 {
+		fields := make([]string, 0, len(context.Prefilled))
+		for _, field := range context.Prefilled {
+			fields = append(fields, prompts.FieldLabel(localeOf(context), field))
+		}
+		message.WriteString(" " + t(context, "import.prefilled", strings.Join(fields, ", ")))
+	}
+	if len(issues) > 0 {
+		lines := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			lines = append(lines, fmt.Sprintf("• %s: %s", prompts.FieldLabel(localeOf(context), issue.field), issue.message))
+		}
+		message.WriteString("\n\n" + t(context, "import.rejected", strings.Join(lines, "\n")))
+	}
+
+	next := pa.skipPrefilled(pa.flow.Start, context)
+	if next == "" || next == "confirmation" {
+		response := pa.confirmationResponse(context)
+		response.Message = message.String() + "\n\n" + response.Message
+		return pa.withHints(response), nil
+	}
+
+	context.CurrentStep = next
+	return pa.withHints(&models.AssistantResponse{
+		Message:        message.String() + "\n\n" + pa.stepPrompt(next, context),
+		ProjectContext: *context,
+	}), nil
+}
+
+// extractBrief запрашивает у Mistral поля проекта в виде структурированного ответа
+func (pa *ProjectAssistant) extractBrief(context *models.ProjectCreationContext, brief string) (*models.ProjectData, error) {
+	if runes := []rune(brief); len(runes) > maxBriefRunes {
+		brief = string(runes[:maxBriefRunes])
+	}
+
+	messages := []models.AssistantMessage{
+		{
+			Role:    "system",
+			Content: withAnswerLanguage(context, fmt.Sprintf(briefSystemPrompt, validator.MaxDescriptionLength)),
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf("Сегодня: %s.\n\nБриф:\n%s", time.Now().Format("02.01.2006"), brief),
+		},
+	}
+
+	response, err := pa.SendMistralSchemaRequest(messages, "project_brief", briefSchema)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при разборе брифа: %w", err)
+	}
+
+	var extracted models.ProjectData
+	if err := json.Unmarshal([]byte(response), &extracted); err != nil {
+		return nil, fmt.Errorf("некорректный ответ модели при разборе брифа: %w", err)
+	}
+	return &extracted, nil
+}
+
+// applyBrief переносит извлеченные значения в данные проекта через парсеры и валидаторы шагов,
+// как если бы пользователь ввел их сам. Возвращает поля, которые пришлось отклонить.
+func (pa *ProjectAssistant) applyBrief(context *models.ProjectCreationContext, extracted *models.ProjectData) []importIssue {
+	locale := localeOf(context)
+	var issues []importIssue
+
+	for i := range pa.flow.Steps {
+		step := &pa.flow.Steps[i]
+		if step.Field == "" || step.Field == "team" {
+			continue
+		}
+		value, _ := extracted.Field(step.Field)
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		if parse, ok := pa.parsers[step.Parser]; ok {
+			parsed, err := parse(value)
+			if err != nil {
+				issues = append(issues, importIssue{field: step.Field, message: validator.Message(err, locale)})
+				continue
+			}
+			value = parsed
+		}
+
+		previous, _ := context.ProjectData.Field(step.Field)
+		context.ProjectData.SetField(step.Field, value)
+		if step.Validator != "" {
+			if err := validator.ValidateProjectStep(step.Validator, context.ProjectData); err != nil {
+				context.ProjectData.SetField(step.Field, previous)
+				issues = append(issues, importIssue{field: step.Field, message: validator.Message(err, locale)})
+				continue
+			}
+		}
+		context.Prefilled = append(context.Prefilled, step.Field)
+	}
+
+	return issues
+}
+
+// applyBriefTeam добавляет в команду участников брифа, найденных в сервисе авторизации. Имена
+// и email из брифа могут быть неточными или придуманными моделью, поэтому данные участника берутся
+// из профиля, а из брифа - только роль. Ненайденные участники попадают в отчет, и тогда шаг
+// команды не пропускается.
+func (pa *ProjectAssistant) applyBriefTeam(context *models.ProjectCreationContext, extracted []models.TeamMember, lookup MemberLookup) []importIssue {
+	if len(extracted) == 0 {
+		return nil
+	}
+	locale := localeOf(context)
+
+	requests := make([]memberRequest, 0, len(extracted))
+	emails := make([]string, 0, len(extracted))
+	for _, member := range extracted {
+		req := memberRequest{
+			raw:   strings.TrimSpace(member.Name + " " + member.Lastname),
+			email: strings.ToLower(strings.TrimSpace(member.Email)),
+			role:  i18n.NormalizeRole(locale, member.Role),
+		}
+		if req.raw == "" {
+			req.raw = req.email
+		}
+		if req.role == "" {
+			req.role = defaultMemberRole
+		}
+		if req.email != "" {
+			emails = append(emails, req.email)
+		}
+		requests = append(requests, req)
+	}
+
+	var known map[string]string
+	if len(emails) > 0 && lookup != nil {
+		known = lookup(emails)
+	}
+	users := pa.prefetchUsers(requests, known)
+
+	var issues []importIssue
+	resolved := 0
+	for _, req := range requests {
+		if req.email == "" {
+			issues = append(issues, importIssue{field: "team", message: t(context, "import.member_without_email", req.raw)})
+			continue
+		}
+		user, err := pa.resolveUser(req, users, known)
+		if err != nil {
+			issues = append(issues, importIssue{field: "team", message: fmt.Sprintf("%s: %s", req.raw, describeLookupError(locale, err))})
+			continue
+		}
+		if isTeamMember(context.ProjectData.Team, user.GetId(), user.GetEmail()) {
+			resolved++
+			continue
+		}
+
+		member := models.TeamMember{
+			ID:       user.GetId(),
+			Name:     user.GetName(),
+			Lastname: user.GetLastname(),
+			Email:    user.GetEmail(),
+			Role:     req.role,
+			Photo:    user.GetPhoto(),
+		}
+		if err := validator.ValidateProjectStep("team", &models.ProjectData{Team: []models.TeamMember{member}}); err != nil {
+			issues = append(issues, importIssue{field: "team", message: fmt.Sprintf("%s: %s", req.raw, validator.Message(err, locale))})
+			continue
+		}
+		context.ProjectData.Team = append(context.ProjectData.Team, member)
+		resolved++
+	}
+
+	if resolved == len(requests) {
+		context.Prefilled = append(context.Prefilled, "team")
+	}
+	return issues
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+// defaultCurrency используется, если валюта в сумме не указана
+const defaultCurrency = "RUB"
+
+var (
+	budgetTokenRegex    = regexp.MustCompile(`\d+(?:[.,]\d+)?|[a-zа-яё]+|[₽$€]`)
+	thousandsGroupRegex = regexp.MustCompile(`(\d)[\s\x{00a0},](\d{3})(\D|$)`)
+	// budgetDashRangeRegex распознает диапазон через тире: "100-200 тыс", "1–2 млн"
+	budgetDashRangeRegex = regexp.MustCompile(`\d\s*[-–—]\s*\d`)
+
+	// rangeWords - слова диапазона: "от 100 до 200 тысяч", "between 1 and 2 million".
+	// "до" и "to" без числа перед ними означают верхнюю границу ("до 500 тысяч") и принимаются.
+	rangeWords = map[string]bool{"до": true, "to": true, "между": true, "between": true}
+
+	// Коды и символы валют должны совпадать целиком, иначе "eur" находился бы в "europe",
+	// а "евро" в "европейский"
+	currencyWords = []struct {
+		stem  string
+		exact bool
+		code  string
+	}{
+		{stem: "руб", code: "RUB"},
+		{stem: "ruble", code: "RUB"},
+		{stem: "rub", exact: true, code: "RUB"},
+		{stem: "р", exact: true, code: "RUB"},
+		{stem: "₽", exact: true, code: "RUB"},
+		{stem: "доллар", code: "USD"},
+		{stem: "dollar", code: "USD"},
+		{stem: "usd", exact: true, code: "USD"},
+		{stem: "$", exact: true, code: "USD"},
+		{stem: "евро", exact: true, code: "EUR"},
+		{stem: "euro", exact: true, code: "EUR"},
+		{stem: "euros", exact: true, code: "EUR"},
+		{stem: "eur", exact: true, code: "EUR"},
+		{stem: "€", exact: true, code: "EUR"},
+	}
+
+	multiplierWords = []struct {
+		stem  string
+		exact bool
+		value float64
+	}{
+		{stem: "тыс", value: 1e3},
+		{stem: "к", exact: true, value: 1e3},
+		{stem: "k", exact: true, value: 1e3},
+		{stem: "млрд", value: 1e9},
+		{stem: "миллиард", value: 1e9},
+		{stem: "b", exact: true, value: 1e9},
+		{stem: "bn", exact: true, value: 1e9},
+		{stem: "млн", value: 1e6},
+		{stem: "миллион", value: 1e6},
+		{stem: "м", exact: true, value: 1e6},
+		{stem: "m", exact: true, value: 1e6},
+		{stem: "mln", exact: true, value: 1e6},
+	}
+
+	numberWords = map[string]float64{
+		"ноль": 0, "один": 1, "одна": 1, "одну": 1, "два": 2, "две": 2, "три": 3, "четыре": 4,
+		"пять": 5, "шесть": 6, "семь": 7, "восемь": 8, "девять": 9, "десять": 10,
+		"одиннадцать": 11, "двенадцать": 12, "тринадцать": 13, "четырнадцать": 14, "пятнадцать": 15,
+		"шестнадцать": 16, "семнадцать": 17, "восемнадцать": 18, "девятнадцать": 19,
+		"двадцать": 20, "тридцать": 30, "сорок": 40, "пятьдесят": 50, "шестьдесят": 60,
+		"семьдесят": 70, "восемьдесят": 80, "девяносто": 90,
+		"сто": 100, "двести": 200, "триста": 300, "четыреста": 400, "пятьсот": 500,
+		"шестьсот": 600, "семьсот": 700, "восемьсот": 800, "девятьсот": 900,
+		"полтора": 1.5, "полторы": 1.5,
+	}
+)
+
+// ParseBudget разбирает сумму, записанную цифрами или словами ("два миллиона рублей", "1.5M EUR")
+// и возвращает ее в нормализованном виде "<сумма> <валюта>", например "2000000 RUB".
+// Диапазоны ("от 100 до 200 тысяч") не принимаются: бюджет проекта - одна сумма.
+func ParseBudget(text string) (string, error) {
+	// Убираем разделители разрядов: "2 000 000" и "1,000,000" превращаются в "2000000" и "1000000"
+	normalized := strings.ToLower(text)
+	for thousandsGroupRegex.MatchString(normalized) {
+		normalized = thousandsGroupRegex.ReplaceAllString(normalized, "$1$2$3")
+	}
+	if budgetDashRangeRegex.MatchString(normalized) {
+		return "", validator.NewError("amount_range")
+	}
+	tokens := budgetTokenRegex.FindAllString(normalized, -1)
+
+	var total, current float64
+	currency := ""
+	hasNumber := false
+
+	for _, token := range tokens {
+		if rangeWords[token] && (hasNumber || token == "между" || token == "between") {
+			return "", validator.NewError("amount_range")
+		}
+
+		if value, err := strconv.ParseFloat(strings.Replace(token, ",", ".", 1), 64); err == nil {
+			current += value
+			hasNumber = true
+			continue
+		}
+
+		if value, ok := numberWords[token]; ok {
+			current += value
+			hasNumber = true
+			continue
+		}
+
+		if multiplier, ok := detectMultiplier(token); ok {
+			if current == 0 {
+				current = 1
+			}
+			total += current * multiplier
+			current = 0
+			hasNumber = true
+			continue
+		}
+
+		if code := detectCurrency(token); code != "" && currency == "" {
+			currency = code
+		}
+	}
+	total = math.Round((total+current)*100) / 100
+
+	if !hasNumber {
+		return "", validator.NewError("unrecognized_amount")
+	}
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	if total == 0 {
+		return "0", nil
+	}
+
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(total, 'f', -1, 64), currency), nil
+}
+
+func detectMultiplier(token string) (float64, bool) {
+	for _, mw := range multiplierWords {
+		if mw.exact && token == mw.stem || !mw.exact && strings.HasPrefix(token, mw.stem) {
+			return mw.value, true
+		}
+	}
+	return 0, false
+}
+
+func detectCurrency(token string) string {
+	for _, cw := range currencyWords {
+		if cw.exact && token == cw.stem || !cw.exact && strings.HasPrefix(token, cw.stem) {
+			return cw.code
+		}
+	}
+	return ""
+}
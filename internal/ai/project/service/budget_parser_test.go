@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+func TestParseBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		wantCode string
+	}{
+		{"цифрами", "500000", "500000 RUB", ""},
+		{"разделители разрядов", "2 000 000 руб", "2000000 RUB", ""},
+		{"словами", "два миллиона рублей", "2000000 RUB", ""},
+		{"множитель и код валюты", "1.5M EUR", "1500000 EUR", ""},
+		{"тысячи с сокращением", "300к $", "300000 USD", ""},
+		{"верхняя граница", "до 500 тысяч", "500000 RUB", ""},
+		{"евро словом", "100 тысяч евро", "100000 EUR", ""},
+		{"eur внутри слова", "100k for europe", "100000 RUB", ""},
+		{"евро внутри слова", "100 тысяч на европейский рынок", "100000 RUB", ""},
+		{"rubles", "5000 rubles", "5000 RUB", ""},
+		{"ноль", "0", "0", ""},
+		{"диапазон от до", "от 100 до 200 тысяч", "", "amount_range"},
+		{"диапазон через тире", "100-200 тыс", "", "amount_range"},
+		{"диапазон с длинным тире", "1 – 2 млн", "", "amount_range"},
+		{"between", "between 1 and 2 million", "", "amount_range"},
+		{"from to", "from 10k to 20k", "", "amount_range"},
+		{"без суммы", "пока не знаю", "", "unrecognized_amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBudget(tt.input)
+			if code := validator.Code(err); code != tt.wantCode {
+				t.Fatalf("ParseBudget(%q) error code = %q, want %q (err %v)", tt.input, code, tt.wantCode, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBudget(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
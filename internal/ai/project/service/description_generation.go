@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+const descriptionSystemPrompt = "Ты - специалист по написанию описаний проектов. Твоя задача - создать четкое и структурированное описание проекта на основе краткой информации от пользователя."
+
+func (pa *ProjectAssistant) handleDescriptionGeneration(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	// Формируем промпт для Mistral API
+	messages := []models.AssistantMessage{
+		{
+			Role:    "system",
+			Content: withAnswerLanguage(context, descriptionSystemPrompt),
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf("Создай подробное описание проекта на основе этой информации: %s", userMessage),
+		},
+	}
+
+	// Отправляем запрос к Mistral API
+	response, err := pa.SendMistralRequest(messages)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации описания: %w", err)
+	}
+
+	// Сохраняем описание как предложение: в данные проекта оно попадет только после согласия пользователя
+	context.Suggestion = &models.Suggestion{
+		Field:   "description",
+		Value:   strings.TrimSpace(response),
+		Request: userMessage,
+	}
+
+	return pa.suggestionResponse(t(context, "description.generated"), context), nil
+}
+
+// handleSuggestionReply обрабатывает ответ на предложенное описание: принять, отклонить или доработать
+func (pa *ProjectAssistant) handleSuggestionReply(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	locale := localeOf(context)
+
+	switch {
+	case i18n.IsKeyword(locale, i18n.KeywordYes, userMessage):
+		suggestion := context.Suggestion
+		if err := validator.ValidateProjectStep(suggestion.Field, &models.ProjectData{Description: suggestion.Value}); err != nil {
+			return &models.AssistantResponse{
+				Message:        t(context, "description.invalid_suggestion", validator.Message(err, locale)),
+				ProjectContext: *context,
+			}, nil
+		}
+
+		context.ProjectData.Description = suggestion.Value
+		context.Suggestion = nil
+
+		if step, ok := pa.flow.Step(context.CurrentStep); ok && step.Field == suggestion.Field {
+			return pa.advance(context, step, false), nil
+		}
+		return &models.AssistantResponse{
+			Message:        t(context, "description.saved", pa.stepPrompt(context.CurrentStep, context)),
+			ProjectContext: *context,
+		}, nil
+
+	case i18n.IsKeyword(locale, i18n.KeywordNo, userMessage):
+		context.Suggestion = nil
+		return &models.AssistantResponse{
+			Message:        t(context, "description.rejected", pa.stepPrompt(context.CurrentStep, context)),
+			ProjectContext: *context,
+		}, nil
+	}
+
+	return pa.refineSuggestion(userMessage, context)
+}
+
+// refineSuggestion дорабатывает предложенное описание по замечаниям пользователя
+func (pa *ProjectAssistant) refineSuggestion(instruction string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	suggestion := context.Suggestion
+
+	messages := []models.AssistantMessage{
+		{
+			Role:    "system",
+			Content: withAnswerLanguage(context, fmt.Sprintf("%s Описание не должно превышать %d символов.", descriptionSystemPrompt, validator.MaxDescriptionLength)),
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf("Создай подробное описание проекта на основе этой информации: %s", suggestion.Request),
+		},
+		{
+			Role:    "assistant",
+			Content: suggestion.Value,
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf("Доработай описание: %s. Верни только новый текст описания.", instruction),
+		},
+	}
+
+	response, err := pa.SendMistralRequest(messages)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при доработке описания: %w", err)
+	}
+
+	suggestion.Value = strings.TrimSpace(response)
+	return pa.suggestionResponse(t(context, "description.refined"), context), nil
+}
+
+// suggestionResponse показывает предложенное описание и варианты ответа
+func (pa *ProjectAssistant) suggestionResponse(header string, context *models.ProjectCreationContext) *models.AssistantResponse {
+	value := context.Suggestion.Value
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("%s\n\n%s\n\n", header, value))
+	if err := validator.ValidateProjectStep("description", &models.ProjectData{Description: value}); err != nil {
+		message.WriteString(fmt.Sprintf("⚠️ %s\n\n", validator.Message(err, localeOf(context))))
+	}
+	message.WriteString(t(context, "description.use_prompt"))
+
+	return &models.AssistantResponse{
+		Message:        message.String(),
+		ProjectContext: *context,
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+// NeedsDuplicateCheck сообщает, нужно ли перед созданием проекта искать проекты с похожим
+// названием: проверка не нужна при редактировании и если пользователь уже решил создать
+// проект с этим названием
+func (pa *ProjectAssistant) NeedsDuplicateCheck(context *models.ProjectCreationContext) bool {
+	return !context.IsEdit() && context.ProjectData != nil && context.ProjectData.Name != context.AcceptedName
+}
+
+// OfferDuplicates откладывает создание проекта и предлагает выбор: переименовать новый проект,
+// открыть существующий или все равно создать
+func (pa *ProjectAssistant) OfferDuplicates(context *models.ProjectCreationContext, matches []models.ProjectMatch) *models.AssistantResponse {
+	context.CurrentStep = "confirmation"
+	context.Duplicates = matches
+
+	lines := make([]string, 0, len(matches))
+	for i, match := range matches {
+		if match.Exact {
+			lines = append(lines, t(context, "duplicate.exact", i+1, match.Name))
+		} else {
+			lines = append(lines, t(context, "duplicate.similar", i+1, match.Name, int(math.Round(match.Similarity*100))))
+		}
+	}
+
+	return pa.withHints(&models.AssistantResponse{
+		Message:        t(context, "duplicate.found", strings.Join(lines, "\n")),
+		ProjectContext: *context,
+	})
+}
+
+// handleDuplicateChoice обрабатывает ответ на предложение о похожих проектах. Любой другой ответ
+// снимает предложение и обрабатывается шагом подтверждения как обычно.
+func (pa *ProjectAssistant) handleDuplicateChoice(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	matches := context.Duplicates
+	context.Duplicates = nil
+
+	choice, index := pa.intents.DetectDuplicateChoice(userMessage)
+	if i18n.IsKeyword(localeOf(context), i18n.KeywordYes, userMessage) {
+		choice = "continue"
+	}
+	switch choice {
+	case "open":
+		if index < 1 || index > len(matches) {
+			context.Duplicates = matches
+			return &models.AssistantResponse{
+				Message:        t(context, "duplicate.pick_range", len(matches)),
+				ProjectContext: *context,
+			}, nil
+		}
+		match := matches[index-1]
+		return &models.AssistantResponse{
+			Message:         t(context, "duplicate.open", match.Name),
+			ProjectContext:  *context,
+			SuggestedAction: "open_project",
+			ProjectID:       match.ID,
+		}, nil
+	case "rename":
+		return pa.handleFieldEdit("name", "", context)
+	case "continue":
+		context.AcceptedName = context.ProjectData.Name
+		return pa.confirmCreation(context), nil
+	}
+	return nil, nil
+}
+
+// duplicateReplies возвращает варианты ответа на предложение о похожих проектах
+func duplicateReplies(context *models.ProjectCreationContext) []models.Choice {
+	replies := []models.Choice{reply(context, "rename")}
+	for i, match := range context.Duplicates {
+		replies = append(replies, models.Choice{
+			Label: t(context, "reply.open", match.Name),
+			Value: fmt.Sprintf("%s %d", t(context, "reply_value.open"), i+1),
+		})
+	}
+	return append(replies, reply(context, "continue"))
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/calendar"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/prompts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
+)
+
+// parserFunc преобразует ввод пользователя в значение поля
+type parserFunc func(message string) (string, error)
+
+// stepHandler обрабатывает шаги, которым недостаточно парсера и валидатора
+type stepHandler func(userMessage string, context *models.ProjectCreationContext, step *wizard.Step) (*models.AssistantResponse, error)
+
+var (
+	statusChoices = []fieldWord{
+		{stem: "1", field: "ЗАПЛАНИРОВАН"},
+		{stem: "заплан", field: "ЗАПЛАНИРОВАН"},
+		{stem: "2", field: "В_ПРОЦЕССЕ"},
+		{stem: "процесс", field: "В_ПРОЦЕССЕ"},
+		{stem: "в работе", field: "В_ПРОЦЕССЕ"},
+		{stem: "3", field: "ПРИОСТАНОВЛЕН"},
+		{stem: "приостанов", field: "ПРИОСТАНОВЛЕН"},
+		{stem: "пауз", field: "ПРИОСТАНОВЛЕН"},
+		{stem: "заверш", field: "ЗАВЕРШЕН"},
+		{stem: "plan", field: "ЗАПЛАНИРОВАН"},
+		{stem: "progress", field: "В_ПРОЦЕССЕ"},
+		{stem: "active", field: "В_ПРОЦЕССЕ"},
+		{stem: "hold", field: "ПРИОСТАНОВЛЕН"},
+		{stem: "pause", field: "ПРИОСТАНОВЛЕН"},
+		{stem: "complet", field: "ЗАВЕРШЕН"},
+	}
+
+	confidentialityChoices = []fieldWord{
+		{stem: "1", field: "Только для участников"},
+		{stem: "участник", field: "Только для участников"},
+		{stem: "команд", field: "Только для участников"},
+		{stem: "2", field: "Вся организация"},
+		{stem: "организац", field: "Вся организация"},
+		{stem: "компан", field: "Вся организация"},
+		{stem: "сотрудник", field: "Вся организация"},
+		{stem: "3", field: "Публичный"},
+		{stem: "публич", field: "Публичный"},
+		{stem: "открыт", field: "Публичный"},
+		{stem: "всем", field: "Публичный"},
+		{stem: "member", field: "Только для участников"},
+		{stem: "team", field: "Только для участников"},
+		{stem: "organi", field: "Вся организация"},
+		{stem: "company", field: "Вся организация"},
+		{stem: "employee", field: "Вся организация"},
+		{stem: "public", field: "Публичный"},
+		{stem: "everyone", field: "Публичный"},
+	}
+)
+
+func (pa *ProjectAssistant) defaultParsers() map[string]parserFunc {
+	return map[string]parserFunc{
+		"text": func(message string) (string, error) {
+			return strings.TrimSpace(message), nil
+		},
+		"date": func(message string) (string, error) {
+			if date := pa.intents.extractDate(message); date != "" {
+				return date, nil
+			}
+			return strings.TrimSpace(message), nil
+		},
+		"priority": func(message string) (string, error) {
+			priority := strings.ToUpper(strings.TrimSpace(message))
+			if err := validator.ValidateProjectStep("priority", &models.ProjectData{Priority: priority}); err == nil {
+				return priority, nil
+			}
+			if detected := i18n.NormalizePriority(i18n.DefaultLocale, message); detected != "" {
+				return detected, nil
+			}
+			return priority, nil
+		},
+		"amount": ParseBudget,
+		"status": func(message string) (string, error) {
+			if status := matchChoice(message, statusChoices); status != "" {
+				return status, nil
+			}
+			return "", validator.NewError("unrecognized_status")
+		},
+		"confidentiality": func(message string) (string, error) {
+			if level := matchChoice(message, confidentialityChoices); level != "" {
+				return level, nil
+			}
+			return "", validator.NewError("unrecognized_confidentiality")
+		},
+	}
+}
+
+func (pa *ProjectAssistant) defaultHandlers() map[string]stepHandler {
+	return map[string]stepHandler{
+		"name":         pa.handleNameStep,
+		"team":         pa.handleTeamStep,
+		"confirmation": pa.handleConfirmationStep,
+	}
+}
+
+// HasParser сообщает, зарегистрирован ли парсер с указанным именем
+func (pa *ProjectAssistant) HasParser(name string) bool {
+	_, ok := pa.parsers[name]
+	return ok
+}
+
+// HasValidator сообщает, есть ли в валидаторе правило с указанным именем
+func (pa *ProjectAssistant) HasValidator(name string) bool {
+	return validator.HasStepRule(name)
+}
+
+// HasHandler сообщает, зарегистрирован ли специальный обработчик шага
+func (pa *ProjectAssistant) HasHandler(name string) bool {
+	_, ok := pa.handlers[name]
+	return ok
+}
+
+// HasField сообщает, может ли общий обработчик шага заполнить поле
+func (pa *ProjectAssistant) HasField(name string) bool {
+	return models.IsSettableField(name)
+}
+
+// HasPrompt сообщает, есть ли подсказка шага в каталоге prompts
+func (pa *ProjectAssistant) HasPrompt(key string) bool {
+	return prompts.HasStepPrompt(key)
+}
+
+// HasMessage сообщает, есть ли сообщение в каталоге i18n
+func (pa *ProjectAssistant) HasMessage(key string) bool {
+	return i18n.Has(key)
+}
+
+// runStep передает сообщение специальному обработчику шага или общему обработчику полей
+func (pa *ProjectAssistant) runStep(step *wizard.Step, userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	if step.Handler != "" {
+		return pa.handlers[step.Handler](userMessage, context, step)
+	}
+	return pa.handleFieldStep(step, userMessage, context)
+}
+
+// handleFieldStep разбирает ввод парсером шага, проверяет его валидатором и переходит дальше
+func (pa *ProjectAssistant) handleFieldStep(step *wizard.Step, userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	if step.Optional && i18n.IsKeyword(localeOf(context), i18n.KeywordSkip, userMessage) {
+		return pa.advance(context, step, true), nil
+	}
+
+	value := strings.TrimSpace(userMessage)
+	if parse, ok := pa.parsers[step.Parser]; ok {
+		parsed, err := parse(userMessage)
+		if err != nil {
+			return pa.stepError(step, err, context), nil
+		}
+		value = parsed
+	}
+
+	previous, _ := context.ProjectData.Field(step.Field)
+	if !context.ProjectData.SetField(step.Field, value) {
+		return nil, fmt.Errorf("шаг %s: поле %s не поддерживается", step.ID, step.Field)
+	}
+
+	if step.Validator != "" {
+		if err := validator.ValidateProjectStep(step.Validator, context.ProjectData); err != nil {
+			context.ProjectData.SetField(step.Field, previous)
+			return pa.stepError(step, err, context), nil
+		}
+	}
+
+	return pa.advance(context, step, false), nil
+}
+
+// advance переводит диалог на следующий шаг. Если пользователь правил отдельное поле
+// на этапе подтверждения, вместо следующего шага возвращает его к подтверждению.
+func (pa *ProjectAssistant) advance(context *models.ProjectCreationContext, step *wizard.Step, skipped bool) *models.AssistantResponse {
+	if context.ReturnStep == "confirmation" {
+		context.ReturnStep = ""
+		return pa.confirmationResponse(context)
+	}
+
+	next := step.Next
+	if skipped {
+		next = step.OnSkip
+	}
+	next = pa.skipPrefilled(next, context)
+	if next == "" {
+		log.Printf("Шаг %s не указывает следующий шаг, переходим к подтверждению", step.ID)
+		return pa.confirmationResponse(context)
+	}
+
+	context.CurrentStep = next
+	message := pa.stepPrompt(next, context)
+	if step.Field != "" {
+		if warnings := validator.FieldWarnings(localeOf(context), context.ProjectData, step.Field); len(warnings) > 0 {
+			message = formatWarnings(context, warnings) + "\n\n" + message
+		}
+	}
+	if step.Field == "deadline" && !skipped {
+		if note := workingDaysNote(context); note != "" {
+			message = note + "\n\n" + message
+		}
+	}
+	return &models.AssistantResponse{
+		Message:        message,
+		ProjectContext: *context,
+	}
+}
+
+// workingDaysNote сообщает, сколько рабочих дней по производственному календарю осталось до дедлайна
+func workingDaysNote(context *models.ProjectCreationContext) string {
+	deadline, err := time.Parse("02.01.2006", context.ProjectData.Deadline)
+	if err != nil {
+		return ""
+	}
+	return t(context, "deadline.working_days", calendar.Default().WorkingDaysBetween(time.Now(), deadline))
+}
+
+// formatWarnings форматирует предупреждения списком. Предупреждения не блокируют переход
+// к следующему шагу, а только обращают внимание пользователя.
+func formatWarnings(context *models.ProjectCreationContext, warnings []string) string {
+	return t(context, "assistant.warnings", "• "+strings.Join(warnings, "\n• "))
+}
+
+// skipPrefilled пропускает шаги, поля которых уже заполнены из шаблона
+func (pa *ProjectAssistant) skipPrefilled(next string, context *models.ProjectCreationContext) string {
+	// Количество переходов ограничено числом шагов, чтобы ошибка в описании мастера не зациклила диалог
+	for i := 0; i < len(pa.flow.Steps) && next != ""; i++ {
+		step, ok := pa.flow.Step(next)
+		if !ok || step.Field == "" || !isPrefilled(context, step.Field) {
+			return next
+		}
+		next = step.Next
+	}
+	return next
+}
+
+func isPrefilled(context *models.ProjectCreationContext, field string) bool {
+	for _, f := range context.Prefilled {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// stepError формирует ответ на ошибку ввода и оставляет пользователя на том же шаге
+func (pa *ProjectAssistant) stepError(step *wizard.Step, err error, context *models.ProjectCreationContext) *models.AssistantResponse {
+	locale := localeOf(context)
+	hint := step.ErrorHint
+	if step.ErrorKey != "" {
+		hint = i18n.T(locale, step.ErrorKey)
+	}
+	if hint == "" {
+		hint = pa.stepPrompt(step.ID, context)
+	}
+	message := validator.Message(err, locale)
+	return &models.AssistantResponse{
+		Message:        fmt.Sprintf("❌ %s\n\n%s", message, hint),
+		ProjectContext: *context,
+		FieldErrors:    map[string]string{fieldPath(step.Field, err): message},
+		Unparsed:       true,
+	}
+}
+
+// fieldPath возвращает путь поля для ошибки ввода с учетом вложенного поля из ошибки валидации,
+// так же как в ValidationState
+func fieldPath(field string, err error) string {
+	var validationErr *validator.Error
+	if errors.As(err, &validationErr) && validationErr.Field != "" {
+		return field + "." + validationErr.Field
+	}
+	return field
+}
+
+// stepPrompt возвращает подсказку шага: из каталога prompts или из шаблона в описании мастера
+func (pa *ProjectAssistant) stepPrompt(stepID string, context *models.ProjectCreationContext) string {
+	step, ok := pa.flow.Step(stepID)
+	if !ok {
+		return ""
+	}
+
+	switch {
+	case step.PromptKey == "confirmation":
+		return pa.confirmationPrompt(context)
+	case step.PromptKey != "":
+		prompt := prompts.GetStepPrompt(localeOf(context), step.PromptKey)
+		if step.Field == "team" && len(context.SuggestedRoles) > 0 {
+			prompt += "\n\n" + t(context, "template.suggested_roles", prompts.FormatTemplateRoles(localeOf(context), context.SuggestedRoles))
+		}
+		return prompt
+	default:
+		prompt, err := step.RenderPrompt(context.ProjectData)
+		if err != nil {
+			log.Printf("Ошибка подготовки подсказки шага %s: %v", step.ID, err)
+		}
+		return prompt
+	}
+}
+
+// matchChoice сопоставляет ответ пользователя с одним из фиксированных вариантов
+func matchChoice(message string, choices []fieldWord) string {
+	message = strings.ToLower(strings.Trim(strings.TrimSpace(message), ".!"))
+	for _, choice := range choices {
+		// Номер варианта должен совпадать целиком, ключевые слова ищем внутри ответа
+		if message == choice.stem || len(choice.stem) > 1 && strings.Contains(message, choice.stem) {
+			return choice.field
+		}
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
+)
+
+// TestDefaultFlowMatchesAssistant проверяет, что встроенное описание мастера ссылается только
+// на парсеры, валидаторы, обработчики и тексты, известные ассистенту
+func TestDefaultFlowMatchesAssistant(t *testing.T) {
+	flow, err := wizard.LoadProjectFlow("")
+	if err != nil {
+		t.Fatalf("LoadProjectFlow: %v", err)
+	}
+	assistant, err := NewProjectAssistant("test-key", "test-model", flow)
+	if err != nil {
+		t.Fatalf("NewProjectAssistant: %v", err)
+	}
+	if err := flow.Check(assistant); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if _, total := flow.Position(flow.Start); total == 0 {
+		t.Fatal("embedded flow has an empty main path")
+	}
+}
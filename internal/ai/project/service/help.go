@@ -0,0 +1,130 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/prompts"
+)
+
+// Категории сообщений, которые различает классификатор справки
+const (
+	helpCategoryHelp     = "help"      // Вопрос о создании проекта или полях мастера
+	helpCategoryAnswer   = "answer"    // Ответ на текущий шаг, сформулированный как вопрос
+	helpCategoryOffTopic = "off_topic" // Сообщение, не связанное с созданием проекта
+)
+
+const helpSystemPrompt = `%s
+
+Сейчас пользователь на шаге мастера "%s". Подсказка этого шага:
+---
+%s
+---
+
+Определи, что прислал пользователь, и верни JSON-объект:
+- category "help" - вопрос о создании проекта, полях мастера или управлении проектами. В reply кратко
+  (не больше 5 предложений) ответь на вопрос с учетом текущего шага. Не заполняй поле за пользователя.
+- category "answer" - это значение для текущего шага, а не вопрос. reply оставь пустым.
+- category "off_topic" - сообщение не связано с созданием проекта. reply оставь пустым.`
+
+// helpSchema описывает ответ классификатора для структурированного вывода Mistral
+var helpSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"category": map[string]interface{}{
+			"type": "string",
+			"enum": []string{helpCategoryHelp, helpCategoryAnswer, helpCategoryOffTopic},
+		},
+		"reply": map[string]interface{}{"type": "string"},
+	},
+	"required":             []string{"category", "reply"},
+	"additionalProperties": false,
+}
+
+// isAssistantCommand сообщает, что сообщение - команда ассистента (генерация названия
+// и описания, план, риски, правка поля). Команды не передаются в режим справки,
+// даже если сформулированы вопросом.
+func (pa *ProjectAssistant) isAssistantCommand(userMessage string, context *models.ProjectCreationContext) bool {
+	switch pa.intents.AnalyzeMessage(userMessage).Type {
+	case "generate_name", "generate_description":
+		return true
+	}
+	if pa.intents.IsPlanRequest(userMessage) || pa.intents.IsPlanRemoval(userMessage) || pa.intents.IsRiskRequest(userMessage) {
+		return true
+	}
+	if _, _, ok := pa.intents.DetectFieldEdit(userMessage); ok && context.CurrentStep == "confirmation" {
+		return true
+	}
+	return false
+}
+
+// handleHelp отвечает на вопрос пользователя с помощью Mistral, опираясь на SystemPrompt
+// и текущий шаг, и оставляет диалог на том же шаге. Сообщения не по теме вежливо отклоняются.
+// Если сообщение оказалось ответом на шаг или Mistral недоступен, возвращает nil,
+// и сообщение обрабатывается шагом как обычно.
+func (pa *ProjectAssistant) handleHelp(userMessage string, context *models.ProjectCreationContext) *models.AssistantResponse {
+	category, reply, err := pa.classifyHelp(userMessage, context)
+	if err != nil {
+		log.Printf("Ошибка режима справки на шаге %s: %v", context.CurrentStep, err)
+		return nil
+	}
+
+	switch category {
+	case helpCategoryHelp:
+		if reply == "" {
+			return nil
+		}
+		return &models.AssistantResponse{
+			Message:        t(context, "help.answer", reply, pa.returnHint(context)),
+			ProjectContext: *context,
+		}
+	case helpCategoryOffTopic:
+		return &models.AssistantResponse{
+			Message:        t(context, "help.off_topic", pa.returnHint(context)),
+			ProjectContext: *context,
+		}
+	default:
+		return nil
+	}
+}
+
+// classifyHelp запрашивает у Mistral категорию сообщения и ответ на вопрос
+func (pa *ProjectAssistant) classifyHelp(userMessage string, context *models.ProjectCreationContext) (string, string, error) {
+	stepPrompt := pa.stepPrompt(context.CurrentStep, context)
+	messages := []models.AssistantMessage{
+		{
+			Role:    "system",
+			Content: withAnswerLanguage(context, fmt.Sprintf(helpSystemPrompt, prompts.SystemPrompt, context.CurrentStep, stepPrompt)),
+		},
+		{
+			Role:    "user",
+			Content: userMessage,
+		},
+	}
+
+	response, err := pa.SendMistralSchemaRequest(messages, "wizard_help", helpSchema)
+	if err != nil {
+		return "", "", err
+	}
+
+	var result struct {
+		Category string `json:"category"`
+		Reply    string `json:"reply"`
+	}
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		return "", "", fmt.Errorf("некорректный ответ классификатора: %w", err)
+	}
+	return result.Category, strings.TrimSpace(result.Reply), nil
+}
+
+// returnHint напоминает, на каком шаге находится пользователь. На шаге подтверждения
+// сводка не повторяется, чтобы не загромождать ответ.
+func (pa *ProjectAssistant) returnHint(context *models.ProjectCreationContext) string {
+	if context.CurrentStep == "confirmation" {
+		return t(context, "assistant.confirm_hint")
+	}
+	return pa.stepPrompt(context.CurrentStep, context)
+}
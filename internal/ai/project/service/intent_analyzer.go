@@ -3,8 +3,10 @@ package service
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
-	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
 )
 
 type Intent struct {
@@ -14,28 +16,113 @@ type Intent struct {
 }
 
 type IntentAnalyzer struct {
-	dateRegex     *regexp.Regexp
-	priorityWords map[string]string
-	helpWords     []string
+	dateRegex             *regexp.Regexp
+	helpWords             []string
+	helpQuestions         []string
+	helpRequests          []string
+	editWords             []string
+	fieldWords            []fieldWord
+	valuePrepositions     []string
+	descriptionGeneration []string
+	planGeneration        []string
+	planRemoval           []string
+	riskAssessment        []string
+	duplicateOpen         []string
+	duplicateRename       []string
+	duplicateContinue     []string
+	templateRegex         *regexp.Regexp
+	userIDRegex           *regexp.Regexp
+	explicitIDRegex       *regexp.Regexp
+	bareIDRegex           *regexp.Regexp
+	numberRegex           *regexp.Regexp
+}
+
+// fieldWord связывает корень слова с полем проекта
+type fieldWord struct {
+	stem  string
+	field string
 }
 
 func NewIntentAnalyzer() *IntentAnalyzer {
 	return &IntentAnalyzer{
 		dateRegex: regexp.MustCompile(`(\d{2})[-./](\d{2})[-./](\d{4})`),
-		priorityWords: map[string]string{
-			"срочн":    "ВЫСОКИЙ",
-			"критичн":  "ВЫСОКИЙ",
-			"важн":     "ВЫСОКИЙ",
-			"средн":    "СРЕДНИЙ",
-			"нормальн": "СРЕДНИЙ",
-			"обычн":    "СРЕДНИЙ",
-			"низк":     "НИЗКИЙ",
-			"потом":    "НИЗКИЙ",
-			"несрочн":  "НИЗКИЙ",
-		},
 		helpWords: []string{
 			"помоги", "придумай", "сгенерируй", "посоветуй", "предложи",
 			"как", "что", "зачем", "почему", "когда",
+			"help", "suggest", "generate", "come up with", "propose",
+		},
+		helpQuestions: []string{
+			"зачем", "почему", "что такое", "что значит", "что означает", "что будет", "как ", "какой", "какая",
+			"какие", "кто ", "где ", "можно ли", "нужно ли", "объясни", "подскажи", "расскажи", "помощь", "справка",
+			"why", "what is", "what's", "what does", "what happens", "how ", "which", "who ", "where ", "can i",
+			"should i", "do i need", "explain", "tell me", "help",
+		},
+		helpRequests: []string{
+			"напиши ", "сделай ", "покажи ", "найди ", "переведи ", "помоги", "дай ", "можешь ", "можете ",
+			"write ", "show me", "give me", "find ", "translate ", "can you", "could you", "would you",
+		},
+		editWords: []string{
+			"поменя", "измени", "исправ", "замени", "смени", "обнови",
+			"change", "edit", "update", "replace", "fix",
+		},
+		valuePrepositions: []string{" на ", " to "},
+		descriptionGeneration: []string{
+			"сгенерируй описание", "generate description", "generate a description",
+		},
+		planGeneration: []string{
+			"составь план", "сгенерируй план", "построй план", "разбей на этапы", "разбей проект",
+			"make a plan", "generate a plan", "generate plan", "create a plan", "break down",
+		},
+		riskAssessment: []string{
+			"оцени риски", "риски проекта", "какие риски", "реестр рисков",
+			"assess risks", "assess the risks", "risk register", "what are the risks",
+		},
+		planRemoval: []string{
+			"удали план", "убери план", "без плана",
+			"remove plan", "remove the plan", "delete plan", "delete the plan", "no plan",
+		},
+		duplicateOpen:     []string{"открой", "открыть", "перейди", "перейти", "open", "go to"},
+		duplicateContinue: []string{"все равно", "всё равно", "продолж", "создай", "создать", "anyway", "continue", "create"},
+		duplicateRename: []string{
+			"переименов", "другое название", "новое название", "rename", "another name", "different name", "new name",
+		},
+		templateRegex: regexp.MustCompile(`(?i)^(?:создай(?:те)?(?: проект)?\s+|create(?: a)?(?: project)?\s+)?(?:по шаблону|from template|using template)\s+(.+)$`),
+		userIDRegex:   regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`),
+		// Числовой ID с пометкой: "id 42", "ID: 42", "#42"
+		explicitIDRegex: regexp.MustCompile(`(?i)(?:(?:^|[^\p{L}])(?:id|ид|айди)\s*[:=#№]?\s*|[#№]\s*)(\d+)\b`),
+		// Число без пометки, рядом с которым может стоять только роль: "42", "42 редактор", "editor 42"
+		bareIDRegex: regexp.MustCompile(`^\s*(?:(\p{L}+)\s+)?(\d+)(?:\s+(\p{L}+))?\s*$`),
+		numberRegex: regexp.MustCompile(`\d+`),
+		fieldWords: []fieldWord{
+			{stem: "назван", field: "name"},
+			{stem: "имя", field: "name"},
+			{stem: "описани", field: "description"},
+			{stem: "дедлайн", field: "deadline"},
+			{stem: "срок", field: "deadline"},
+			{stem: "дат", field: "deadline"},
+			{stem: "приоритет", field: "priority"},
+			{stem: "бюджет", field: "budget"},
+			{stem: "потрач", field: "spent"},
+			{stem: "расход", field: "spent"},
+			{stem: "статус", field: "status"},
+			{stem: "конфиденциальн", field: "confidentiality"},
+			{stem: "видимост", field: "confidentiality"},
+			{stem: "доступ", field: "confidentiality"},
+			{stem: "команд", field: "team"},
+			{stem: "участник", field: "team"},
+			{stem: "name", field: "name"},
+			{stem: "title", field: "name"},
+			{stem: "description", field: "description"},
+			{stem: "deadline", field: "deadline"},
+			{stem: "due date", field: "deadline"},
+			{stem: "priority", field: "priority"},
+			{stem: "budget", field: "budget"},
+			{stem: "spent", field: "spent"},
+			{stem: "status", field: "status"},
+			{stem: "confidential", field: "confidentiality"},
+			{stem: "visibility", field: "confidentiality"},
+			{stem: "team", field: "team"},
+			{stem: "member", field: "team"},
 		},
 	}
 }
@@ -45,10 +132,10 @@ func (ia *IntentAnalyzer) AnalyzeMessage(message string) Intent {
 
 	// Проверка на запрос помощи
 	if ia.containsAny(message, ia.helpWords) {
-		if strings.Contains(message, "описани") {
+		if strings.Contains(message, "описани") || strings.Contains(message, "description") {
 			return Intent{Type: "generate_description", Content: message}
 		}
-		if strings.Contains(message, "назван") {
+		if strings.Contains(message, "назван") || strings.Contains(message, "name") {
 			return Intent{Type: "generate_name", Content: message}
 		}
 		return Intent{Type: "help", Content: message}
@@ -60,7 +147,7 @@ func (ia *IntentAnalyzer) AnalyzeMessage(message string) Intent {
 	}
 
 	// Проверка на приоритет
-	if priority := ia.detectPriority(message); priority != "" {
+	if priority := i18n.NormalizePriority(i18n.DefaultLocale, message); priority != "" {
 		return Intent{Type: "priority", Content: priority}
 	}
 
@@ -72,6 +159,160 @@ func (ia *IntentAnalyzer) AnalyzeMessage(message string) Intent {
 	return Intent{Type: "text", Content: message}
 }
 
+// DetectNavigation распознает команды навигации по шагам ("назад", "отмена", "back", "undo").
+// Команда должна быть единственным содержимым сообщения, чтобы не срабатывать на обычный текст.
+func (ia *IntentAnalyzer) DetectNavigation(message string) string {
+	switch {
+	case i18n.IsKeyword(i18n.DefaultLocale, i18n.KeywordBack, message):
+		return "back"
+	case i18n.IsKeyword(i18n.DefaultLocale, i18n.KeywordUndo, message):
+		return "undo"
+	default:
+		return ""
+	}
+}
+
+// DetectTemplateRequest распознает сообщения вида "по шаблону Онбординг клиента" и возвращает название шаблона
+func (ia *IntentAnalyzer) DetectTemplateRequest(message string) (string, bool) {
+	matches := ia.templateRegex.FindStringSubmatch(strings.TrimSpace(message))
+	if matches == nil {
+		return "", false
+	}
+	name := strings.Trim(strings.TrimSpace(matches[1]), `"'«».!`)
+	return name, name != ""
+}
+
+// IsDescriptionGenerationRequest распознает явную просьбу сгенерировать описание проекта
+func (ia *IntentAnalyzer) IsDescriptionGenerationRequest(message string) bool {
+	return ia.containsAny(strings.ToLower(message), ia.descriptionGeneration)
+}
+
+// IsQuestion распознает вопрос или просьбу: сообщение заканчивается вопросительным знаком
+// или начинается с вопросительного слова либо глагола просьбы. Отличить вопрос от ответа
+// на шаг окончательно помогает классификатор в handleHelp.
+func (ia *IntentAnalyzer) IsQuestion(message string) bool {
+	message = strings.ToLower(strings.TrimSpace(message))
+	if strings.HasSuffix(message, "?") {
+		return true
+	}
+	for _, prefixes := range [][]string{ia.helpQuestions, ia.helpRequests} {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(message, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsPlanRequest распознает просьбу разбить проект на этапы и задачи
+func (ia *IntentAnalyzer) IsPlanRequest(message string) bool {
+	return ia.containsAny(strings.ToLower(message), ia.planGeneration)
+}
+
+// IsPlanRemoval распознает просьбу убрать план из проекта
+func (ia *IntentAnalyzer) IsPlanRemoval(message string) bool {
+	return ia.containsAny(strings.ToLower(message), ia.planRemoval)
+}
+
+// IsRiskRequest распознает просьбу оценить риски проекта
+func (ia *IntentAnalyzer) IsRiskRequest(message string) bool {
+	return ia.containsAny(strings.ToLower(message), ia.riskAssessment)
+}
+
+// DetectDuplicateChoice распознает ответ на предложение о похожих проектах: "open" с номером
+// проекта (по умолчанию 1), "rename" или "continue". Для остальных сообщений возвращает пустую строку.
+func (ia *IntentAnalyzer) DetectDuplicateChoice(message string) (string, int) {
+	lower := strings.ToLower(strings.TrimSpace(message))
+	switch {
+	case ia.containsAny(lower, ia.duplicateOpen):
+		index := 1
+		if number := ia.numberRegex.FindString(lower); number != "" {
+			index, _ = strconv.Atoi(number)
+		}
+		return "open", index
+	case ia.containsAny(lower, ia.duplicateRename):
+		return "rename", 0
+	case ia.containsAny(lower, ia.duplicateContinue):
+		return "continue", 0
+	default:
+		return "", 0
+	}
+}
+
+// DetectFieldEdit распознает запрос на изменение поля вида "поменяй дедлайн на 01.06.2027"
+// или "change deadline to 01.06.2027".
+// Возвращает поле и новое значение (пустое, если значение не указано).
+func (ia *IntentAnalyzer) DetectFieldEdit(message string) (field string, value string, ok bool) {
+	original := strings.TrimSpace(message)
+	lower := strings.ToLower(original)
+	if !ia.containsAny(lower, ia.editWords) {
+		return "", "", false
+	}
+
+	// Ищем самое раннее упоминание поля в сообщении
+	fieldPos := -1
+	fieldEnd := 0
+	for _, fw := range ia.fieldWords {
+		if pos := strings.Index(lower, fw.stem); pos >= 0 && (fieldPos < 0 || pos < fieldPos) {
+			fieldPos = pos
+			fieldEnd = pos + len(fw.stem)
+			field = fw.field
+		}
+	}
+	if fieldPos < 0 {
+		return "", "", false
+	}
+
+	// Значение идет после предлога "на" или "to"; по возможности берем его из исходного сообщения,
+	// чтобы сохранить регистр
+	source := original
+	if len(source) != len(lower) {
+		source = lower
+	}
+	for _, preposition := range ia.valuePrepositions {
+		if idx := strings.Index(lower[fieldEnd:], preposition); idx >= 0 {
+			value = strings.TrimSpace(source[fieldEnd+idx+len(preposition):])
+			break
+		}
+	}
+
+	return field, value, true
+}
+
+// DetectRole определяет роль участника команды по словам вроде "редактор", "viewer", "менеджер".
+// Слова языка пользователя проверяются первыми.
+func (ia *IntentAnalyzer) DetectRole(locale i18n.Locale, message string) string {
+	return i18n.NormalizeRole(locale, message)
+}
+
+// ExtractUserID находит в сообщении идентификатор пользователя: UUID, число с пометкой "id" или "#"
+// либо число, рядом с которым указана только роль. Прочие числа в тексте идентификатором не считаются.
+func (ia *IntentAnalyzer) ExtractUserID(message string) string {
+	if id := ia.userIDRegex.FindString(message); id != "" {
+		return id
+	}
+	if matches := ia.explicitIDRegex.FindStringSubmatch(message); matches != nil {
+		return matches[1]
+	}
+
+	matches := ia.bareIDRegex.FindStringSubmatch(message)
+	if matches == nil {
+		return ""
+	}
+	for _, word := range []string{matches[1], matches[3]} {
+		if word != "" && i18n.NormalizeRole(i18n.DefaultLocale, word) == "" {
+			return ""
+		}
+	}
+	return matches[2]
+}
+
+// ExtractEmail находит в сообщении email
+func (ia *IntentAnalyzer) ExtractEmail(message string) string {
+	return ia.extractEmail(message)
+}
+
 func (ia *IntentAnalyzer) extractDate(message string) string {
 	matches := ia.dateRegex.FindStringSubmatch(message)
 	if len(matches) == 4 {
@@ -81,15 +322,6 @@ func (ia *IntentAnalyzer) extractDate(message string) string {
 	return ""
 }
 
-func (ia *IntentAnalyzer) detectPriority(message string) string {
-	for word, priority := range ia.priorityWords {
-		if strings.Contains(message, word) {
-			return priority
-		}
-	}
-	return ""
-}
-
 func (ia *IntentAnalyzer) extractEmail(message string) string {
 	emailRegex := regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
 	if email := emailRegex.FindString(message); email != "" {
@@ -106,41 +338,3 @@ func (ia *IntentAnalyzer) containsAny(message string, words []string) bool {
 	}
 	return false
 }
-
-// Дополнительные методы анализа
-func (ia *IntentAnalyzer) AnalyzeContext(message string, currentStep string) (string, map[string]string) {
-	context := make(map[string]string)
-
-	switch currentStep {
-	case "name":
-		// Анализ возможных улучшений названия
-		words := strings.Fields(message)
-		if len(words) < 2 {
-			context["suggestion"] = "maybe_extend"
-		}
-		if strings.Contains(message, "проект") {
-			context["has_project_word"] = "true"
-		}
-
-	case "description":
-		// Анализ информативности описания
-		words := strings.Fields(message)
-		if len(words) < 10 {
-			context["suggestion"] = "too_short"
-		}
-		if !strings.Contains(strings.ToLower(message), "цель") {
-			context["missing"] = "goals"
-		}
-
-	case "deadline":
-		// Анализ адекватности дедлайна
-		if date := ia.extractDate(message); date != "" {
-			parsedDate, _ := time.Parse("02.01.2006", date)
-			if parsedDate.Sub(time.Now()) < 7*24*time.Hour {
-				context["warning"] = "too_soon"
-			}
-		}
-	}
-
-	return currentStep, context
-}
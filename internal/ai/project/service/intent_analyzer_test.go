@@ -0,0 +1,29 @@
+package service
+
+import "testing"
+
+func TestIsQuestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    bool
+	}{
+		{"вопросительный знак", "Это обязательно?", true},
+		{"вопросительное слово", "зачем нужен бюджет", true},
+		{"просьба", "Напиши стих про котов", true},
+		{"can you", "can you book a flight for me", true},
+		{"название", "CRM для отдела продаж", false},
+		{"описание", "Мобильное приложение для доставки еды по городу", false},
+		{"english name", "Analytics dashboard", false},
+		{"слово просьбы внутри", "Сервис, который найдет курьера", false},
+	}
+
+	analyzer := NewIntentAnalyzer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := analyzer.IsQuestion(tt.message); got != tt.want {
+				t.Errorf("IsQuestion(%q) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
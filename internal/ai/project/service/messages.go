@@ -0,0 +1,249 @@
+package service
+
+import "github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+
+func init() {
+	i18n.Register(i18n.RU, map[string]string{
+		"assistant.first_step":        "Это первый шаг, возвращаться некуда.\n\n%s",
+		"assistant.went_back":         "↩️ Вернулись к предыдущему шагу, прежние значения восстановлены.\n\n%s",
+		"assistant.validation_failed": "❌ Обнаружены ошибки:\n%s\n\nПожалуйста, исправьте их и попробуйте снова.",
+		"assistant.creating":          "✅ Отлично! Создаю проект...",
+		"assistant.created":           "🎉 Проект «%s» создан!",
+		"assistant.creation_pending":  "⏳ Сервис проектов временно недоступен. Данные сохранены, проект будет создан автоматически — статус можно проверить по creation_id.",
+		"assistant.creation_retry":    "⏳ Сервис проектов временно недоступен. Данные сохранены — повторите подтверждение позже, ответив \"да\".",
+		"assistant.creation_failed":   "❌ Не удалось создать проект. Данные сохранены, обратитесь в поддержку и укажите creation_id.",
+		"assistant.updating":          "✅ Сохраняю изменения...",
+		"assistant.updated":           "🎉 Изменения в проекте «%s» сохранены!",
+		"assistant.update_failed":     "❌ Не удалось сохранить изменения: сервис проектов недоступен. Правки не потеряны, ответьте \"да\", чтобы попробовать еще раз.",
+		"assistant.restart":           "Хорошо, давайте начнем сначала.\n\n%s",
+		"assistant.confirm_hint":      "Пожалуйста, ответьте 'да' или 'нет' либо укажите, какое поле нужно изменить.",
+		"assistant.answer_language":   "Отвечай на русском языке.",
+		"assistant.warnings":          "⚠️ Обратите внимание:\n%s",
+
+		"plan.generated": "🗺 Составил план проекта, он добавлен в сводку.",
+		"plan.failed":    "❌ Не удалось составить план: %s.\n\nПопробуйте еще раз или продолжите без плана.",
+		"plan.removed":   "План удален из проекта.",
+
+		"risk.assessed": "🧭 Составил реестр рисков: %d шт. Самые серьезные показаны под сводкой.",
+		"risk.failed":   "❌ Не удалось оценить риски: %s.\n\nПопробуйте еще раз или продолжите без оценки.",
+
+		"deadline.working_days": "📅 До дедлайна осталось рабочих дней: %d.",
+
+		"help.answer":    "💡 %s\n\n%s",
+		"help.off_topic": "🙂 Я помогаю только с созданием проектов, поэтому с этим помочь не смогу. Давайте продолжим.\n\n%s",
+
+		"import.done":                 "📥 Бриф разобран.",
+		"import.prefilled":            "Заполнены поля: %s. Их можно изменить на шаге подтверждения.",
+		"import.rejected":             "Эти данные из брифа не подошли, их нужно ввести заново:\n%s",
+		"import.member_without_email": "%s: в брифе не указан email участника",
+
+		"draft.resumed":      "👋 Продолжаем создание проекта с того места, где вы остановились.\n\n%s",
+		"draft.resumed_edit": "👋 Продолжаем редактирование проекта с того места, где вы остановились.\n\n%s",
+
+		"edit.started":    "✏️ Редактируем проект «%s». Текущие данные:\n%s\n%s",
+		"edit.no_changes": "Вы пока ничего не изменили.\n\n%s",
+		"edit.reset":      "↩️ Все изменения отменены.\n\n%s",
+
+		"reply.yes":         "Да",
+		"reply.no":          "Нет",
+		"reply.skip":        "Пропустить",
+		"reply.done":        "Готово",
+		"reply.list":        "Показать команду",
+		"reply.more":        "Другие варианты",
+		"reply.create":      "Да, создать проект",
+		"reply.save":        "Сохранить изменения",
+		"reply.edit":        "Изменить",
+		"reply.discard":     "Отменить изменения",
+		"reply.plan":        "Составить план",
+		"reply.remove_plan": "Удалить план",
+		"reply.risks":       "Оценить риски",
+		"reply.rename":      "Переименовать",
+		"reply.open":        "Открыть «%s»",
+		"reply.continue":    "Все равно создать",
+
+		"reply_value.yes":         "да",
+		"reply_value.no":          "нет",
+		"reply_value.skip":        "пропустить",
+		"reply_value.done":        "готово",
+		"reply_value.list":        "список",
+		"reply_value.more":        "ещё",
+		"reply_value.create":      "да",
+		"reply_value.save":        "да",
+		"reply_value.edit":        "нет",
+		"reply_value.discard":     "заново",
+		"reply_value.plan":        "составь план",
+		"reply_value.remove_plan": "удали план",
+		"reply_value.risks":       "оцени риски",
+		"reply_value.rename":      "переименовать",
+		"reply_value.open":        "открыть",
+		"reply_value.continue":    "все равно создать",
+
+		"duplicate.found":      "🔎 Похоже, такой проект уже есть:\n%s\n\nНапишите \"переименовать\", чтобы выбрать другое название, \"открыть\" (или \"открыть 2\"), чтобы перейти к существующему проекту, или \"все равно создать\".",
+		"duplicate.exact":      "%d. «%s» — такое же название",
+		"duplicate.similar":    "%d. «%s» — похожее название (%d%%)",
+		"duplicate.pick_range": "❌ Укажите номер проекта от 1 до %d.",
+		"duplicate.open":       "📂 Открываю проект «%s». Новый проект не создан.",
+
+		"template.applied":         "📄 Создаем проект по шаблону «%s».",
+		"template.prefilled":       "Из шаблона заполнены поля: %s. Их можно изменить на шаге подтверждения.",
+		"template.suggested_roles": "Шаблон рекомендует собрать команду:\n%s",
+		"template.not_found":       "❌ Шаблон «%s» не найден.",
+
+		"hint.name":            "Пожалуйста, введите корректное название проекта.",
+		"hint.description":     "Пожалуйста, введите корректное описание проекта.",
+		"hint.deadline":        "Пожалуйста, введите корректную дату в формате ДД.ММ.ГГГГ.",
+		"hint.priority":        "Пожалуйста, выберите один из вариантов: высокий, средний или низкий.",
+		"hint.budget":          "Пожалуйста, укажите сумму, например: 150000 руб, или напишите \"пропустить\".",
+		"hint.spent":           "Пожалуйста, укажите сумму, например: 50000 руб.",
+		"hint.status":          "Не удалось распознать статус.",
+		"hint.confidentiality": "Пожалуйста, выберите один из предложенных вариантов.",
+
+		"name.pick_range":        "❌ Выберите номер от 1 до %d или попросите другие варианты.",
+		"name.none":              "😔 Не удалось придумать подходящее название. Попросите еще раз, рассказав подробнее о проекте (например, \"придумай название для портала поставщиков\"), или введите название самостоятельно.",
+		"name.need_brief":        "✏️ Чтобы предложить подходящие названия, опишите коротко, о чем проект: одной-двумя фразами.",
+		"name.candidates_header": "✨ Вот несколько вариантов названия:\n\n",
+		"name.candidates_footer": "\nНапишите номер понравившегося варианта, попросите \"ещё\" или введите своё название.",
+
+		"description.generated":          "✨ Я сгенерировал следующее описание для вашего проекта:",
+		"description.refined":            "✏️ Обновленное описание:",
+		"description.invalid_suggestion": "❌ %s\n\nПопросите доработать описание, например: \"сделай короче\", или ответьте \"нет\", чтобы ввести его самостоятельно.",
+		"description.saved":              "✅ Описание сохранено.\n\n%s",
+		"description.rejected":           "Хорошо, описание не сохранено.\n\n%s",
+		"description.use_prompt":         "Хотите использовать это описание? Ответьте 'да' или 'нет', либо напишите, что изменить (например, \"сделай короче\" или \"добавь цели\").",
+
+		"team.no_members_found": "❌ Не удалось найти email или ID участника.",
+		"team.not_understood":   "❓ Не понял, кого добавить: %s",
+		"team.already_member":   "⚠️ %s уже есть в команде.",
+		"team.added":            "✅ Добавлен(а) %s %s — %s",
+		"team.remove_target":    "❌ Укажите email или ID участника, которого нужно удалить.",
+		"team.member_not_found": "❌ Участник %s не найден в команде.",
+		"team.removed":          "🗑 %s %s удален(а) из команды.",
+		"team.current":          "👥 Текущая команда:",
+		"team.next_hint":        "Добавьте следующего участника, удалите участника командой \"удали <email или ID>\" или напишите \"готово\".",
+		"team.email_not_found":  "пользователь с таким email не найден среди участников ваших проектов: по email ищутся только коллеги из первых 20 проектов, остальных укажите по ID",
+		"team.user_not_found":   "пользователь не найден",
+		"team.lookup_failed":    "не удалось получить данные пользователя, попробуйте позже",
+	})
+
+	i18n.Register(i18n.EN, map[string]string{
+		"assistant.first_step":        "This is the first step, there is nowhere to go back to.\n\n%s",
+		"assistant.went_back":         "↩️ Back to the previous step, the previous values have been restored.\n\n%s",
+		"assistant.validation_failed": "❌ Some fields are invalid:\n%s\n\nPlease fix them and try again.",
+		"assistant.creating":          "✅ Great! Creating the project...",
+		"assistant.created":           "🎉 The \"%s\" project has been created!",
+		"assistant.creation_pending":  "⏳ The project service is temporarily unavailable. Your data is saved and the project will be created automatically — you can check the status using creation_id.",
+		"assistant.creation_retry":    "⏳ The project service is temporarily unavailable. Your data is saved — confirm again later by answering \"yes\".",
+		"assistant.creation_failed":   "❌ The project could not be created. Your data is saved, please contact support and provide the creation_id.",
+		"assistant.updating":          "✅ Saving the changes...",
+		"assistant.updated":           "🎉 The changes to the \"%s\" project have been saved!",
+		"assistant.update_failed":     "❌ The changes could not be saved: the project service is unavailable. Your edits are kept, answer \"yes\" to try again.",
+		"assistant.restart":           "OK, let's start over.\n\n%s",
+		"assistant.confirm_hint":      "Please answer 'yes' or 'no', or tell me which field should be changed.",
+		"assistant.answer_language":   "Answer in English.",
+		"assistant.warnings":          "⚠️ Please note:\n%s",
+
+		"plan.generated": "🗺 I've drafted a project plan and added it to the summary.",
+		"plan.failed":    "❌ Could not draft a plan: %s.\n\nTry again or continue without a plan.",
+		"plan.removed":   "The plan has been removed from the project.",
+
+		"risk.assessed": "🧭 I've compiled a risk register with %d risks. The most serious ones are shown below the summary.",
+		"risk.failed":   "❌ Could not assess the risks: %s.\n\nTry again or continue without the assessment.",
+
+		"deadline.working_days": "📅 Working days left until the deadline: %d.",
+
+		"help.answer":    "💡 %s\n\n%s",
+		"help.off_topic": "🙂 I can only help with creating projects, so I can't help with that. Let's continue.\n\n%s",
+
+		"import.done":                 "📥 The brief has been processed.",
+		"import.prefilled":            "Filled fields: %s. You can change them at the confirmation step.",
+		"import.rejected":             "These values from the brief could not be accepted and need to be entered again:\n%s",
+		"import.member_without_email": "%s: the brief does not include the member's email",
+
+		"draft.resumed":      "👋 Let's continue creating the project where you left off.\n\n%s",
+		"draft.resumed_edit": "👋 Let's continue editing the project where you left off.\n\n%s",
+
+		"edit.started":    "✏️ Editing the \"%s\" project. Current details:\n%s\n%s",
+		"edit.no_changes": "You haven't changed anything yet.\n\n%s",
+		"edit.reset":      "↩️ All changes have been discarded.\n\n%s",
+
+		"reply.yes":         "Yes",
+		"reply.no":          "No",
+		"reply.skip":        "Skip",
+		"reply.done":        "Done",
+		"reply.list":        "Show team",
+		"reply.more":        "More options",
+		"reply.create":      "Yes, create the project",
+		"reply.save":        "Save changes",
+		"reply.edit":        "Change",
+		"reply.discard":     "Discard changes",
+		"reply.plan":        "Make a plan",
+		"reply.remove_plan": "Remove the plan",
+		"reply.risks":       "Assess risks",
+		"reply.rename":      "Rename",
+		"reply.open":        "Open \"%s\"",
+		"reply.continue":    "Create anyway",
+
+		"reply_value.yes":         "yes",
+		"reply_value.no":          "no",
+		"reply_value.skip":        "skip",
+		"reply_value.done":        "done",
+		"reply_value.list":        "list",
+		"reply_value.more":        "more",
+		"reply_value.create":      "yes",
+		"reply_value.save":        "yes",
+		"reply_value.edit":        "no",
+		"reply_value.discard":     "restart",
+		"reply_value.plan":        "make a plan",
+		"reply_value.remove_plan": "remove the plan",
+		"reply_value.risks":       "assess risks",
+		"reply_value.rename":      "rename",
+		"reply_value.open":        "open",
+		"reply_value.continue":    "create anyway",
+
+		"duplicate.found":      "🔎 It looks like this project already exists:\n%s\n\nType \"rename\" to choose another name, \"open\" (or \"open 2\") to go to the existing project, or \"create anyway\".",
+		"duplicate.exact":      "%d. \"%s\" — same name",
+		"duplicate.similar":    "%d. \"%s\" — similar name (%d%%)",
+		"duplicate.pick_range": "❌ Choose a project number from 1 to %d.",
+		"duplicate.open":       "📂 Opening the \"%s\" project. No new project was created.",
+
+		"template.applied":         "📄 Creating the project from the \"%s\" template.",
+		"template.prefilled":       "The following fields were filled from the template: %s. You can change them at the confirmation step.",
+		"template.suggested_roles": "The template suggests the following team:\n%s",
+		"template.not_found":       "❌ Template \"%s\" was not found.",
+
+		"hint.name":            "Please enter a valid project name.",
+		"hint.description":     "Please enter a valid project description.",
+		"hint.deadline":        "Please enter a valid date in the DD.MM.YYYY format.",
+		"hint.priority":        "Please choose one of the options: high, medium or low.",
+		"hint.budget":          "Please enter an amount, for example: 150000 USD, or type \"skip\".",
+		"hint.spent":           "Please enter an amount, for example: 50000 USD.",
+		"hint.status":          "The status was not recognized.",
+		"hint.confidentiality": "Please choose one of the suggested options.",
+
+		"name.pick_range":        "❌ Choose a number from 1 to %d or ask for other options.",
+		"name.none":              "😔 I couldn't come up with a suitable name. Ask again with more details about the project (for example, \"suggest a name for a supplier portal\") or enter a name yourself.",
+		"name.need_brief":        "✏️ To suggest fitting names, briefly describe what the project is about in a sentence or two.",
+		"name.candidates_header": "✨ Here are a few name options:\n\n",
+		"name.candidates_footer": "\nType the number of the option you like, ask for \"more\" or enter your own name.",
+
+		"description.generated":          "✨ Here is the description I generated for your project:",
+		"description.refined":            "✏️ Updated description:",
+		"description.invalid_suggestion": "❌ %s\n\nAsk me to refine the description, for example: \"make it shorter\", or answer \"no\" to write it yourself.",
+		"description.saved":              "✅ Description saved.\n\n%s",
+		"description.rejected":           "OK, the description was not saved.\n\n%s",
+		"description.use_prompt":         "Do you want to use this description? Answer 'yes' or 'no', or tell me what to change (for example, \"make it shorter\" or \"add goals\").",
+
+		"team.no_members_found": "❌ No member email or ID found.",
+		"team.not_understood":   "❓ I didn't understand whom to add: %s",
+		"team.already_member":   "⚠️ %s is already on the team.",
+		"team.added":            "✅ Added %s %s — %s",
+		"team.remove_target":    "❌ Specify the email or ID of the member to remove.",
+		"team.member_not_found": "❌ Member %s is not on the team.",
+		"team.removed":          "🗑 %s %s has been removed from the team.",
+		"team.current":          "👥 Current team:",
+		"team.next_hint":        "Add the next member, remove a member with \"remove <email or ID>\" or type \"done\".",
+		"team.email_not_found":  "no member of your projects has this email: emails are only looked up among collaborators on your first 20 projects, please use the user ID for anyone else",
+		"team.user_not_found":   "user not found",
+		"team.lookup_failed":    "failed to load user details, please try again later",
+	})
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
+)
+
+const (
+	minNameCandidates = 3
+	maxNameCandidates = 5
+	// maxNameAttempts ограничивает количество запросов к Mistral за одну генерацию
+	maxNameAttempts = 3
+	// minNameBriefWords - сколько слов о проекте должно остаться в запросе на генерацию
+	// после команды, чтобы не спрашивать краткое описание
+	minNameBriefWords = 2
+)
+
+var (
+	candidatePickRegex   = regexp.MustCompile(`^(?:вариант\s*|option\s*|№\s*|#\s*)?(\d+)[.)]?$`)
+	candidatePrefixRegex = regexp.MustCompile(`^\s*(?:\d+[.)]|[-•*])\s*`)
+	// nameRequestWordRegex - слова самой просьбы придумать название, не говорящие ничего о проекте.
+	// Слово должно совпасть целиком: изменяемые слова перечислены основой с \p{L}* или с окончаниями,
+	// иначе короткие слова вроде "a" или "me" отбрасывали бы "app" и "media"
+	nameRequestWordRegex = regexp.MustCompile(`^(?:` +
+		`помо\p{L}*|придума\p{L}*|сгенерир\p{L}*|посовет\p{L}*|предлож\p{L}*|` +
+		`назван(?:ие|ия|ию|ием|ии|ий|иям|иями|иях)|проект(?:а|у|ом|е|ы|ов|ам|ами|ах)?|вариант(?:а|у|ом|е|ы|ов|ам|ами|ах)?|` +
+		`пожалуйста|мне|нам|для|какое|какие|какой|нибудь|хорош\p{L}*|красив\p{L}*|` +
+		`help\p{L}*|suggest\p{L}*|generat\p{L}*|come|up|with|propos\p{L}*|names?|naming|projects?|options?|` +
+		`please|me|us|for|some|good|nice|a|an|the` +
+		`)$`)
+	wordRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+// handleNameCandidates обрабатывает выбор сгенерированного названия по номеру или запрос новых вариантов.
+// Возвращает nil, если сообщение не относится к выбору варианта.
+func (pa *ProjectAssistant) handleNameCandidates(userMessage string, context *models.ProjectCreationContext, step *wizard.Step) (*models.AssistantResponse, error) {
+	answer := strings.ToLower(strings.TrimSpace(userMessage))
+
+	if matches := candidatePickRegex.FindStringSubmatch(answer); matches != nil {
+		index, _ := strconv.Atoi(matches[1])
+		if index < 1 || index > len(context.NameCandidates) {
+			return &models.AssistantResponse{
+				Message:        t(context, "name.pick_range", len(context.NameCandidates)),
+				ProjectContext: *context,
+			}, nil
+		}
+		name := context.NameCandidates[index-1]
+		context.NameCandidates = nil
+		return pa.handleFieldStep(step, name, context)
+	}
+
+	if i18n.IsKeyword(localeOf(context), i18n.KeywordMore, answer) {
+		return pa.handleNameGeneration("", context)
+	}
+
+	return nil, nil
+}
+
+// handleNameBrief принимает краткое описание проекта, о котором ассистент попросил перед генерацией
+// названия, и предлагает варианты
+func (pa *ProjectAssistant) handleNameBrief(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	context.AwaitNameBrief = false
+	context.NameBrief = strings.TrimSpace(context.NameBrief + " " + strings.TrimSpace(userMessage))
+	return pa.handleNameGeneration("", context)
+}
+
+// handleNameGeneration генерирует варианты названия проекта на основе описания проекта, краткого
+// описания из запроса или ответа на вопрос о проекте. Без этих сведений сначала просит коротко
+// описать проект. Предлагает от minNameCandidates до maxNameCandidates вариантов; если подходящих
+// меньше, сообщает, что придумать не удалось.
+func (pa *ProjectAssistant) handleNameGeneration(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	if brief := nameRequestBrief(userMessage); brief != "" {
+		context.NameBrief = brief
+	}
+	source := strings.TrimSpace(strings.TrimSpace(context.ProjectData.Description) + " " + context.NameBrief)
+	if source == "" {
+		context.AwaitNameBrief = true
+		return &models.AssistantResponse{
+			Message:        t(context, "name.need_brief"),
+			ProjectContext: *context,
+		}, nil
+	}
+
+	previous := context.NameCandidates
+	candidates := make([]string, 0, maxNameCandidates)
+	seen := make(map[string]bool)
+	for _, name := range previous {
+		seen[strings.ToLower(name)] = true
+	}
+
+	for attempt := 0; attempt < maxNameAttempts && len(candidates) < minNameCandidates; attempt++ {
+		exclude := append(append([]string(nil), previous...), candidates...)
+		generated, err := pa.requestNameCandidates(context, source, exclude)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при генерации названия: %w", err)
+		}
+
+		for _, name := range generated {
+			if len(candidates) == maxNameCandidates {
+				break
+			}
+			key := strings.ToLower(name)
+			if seen[key] {
+				continue
+			}
+			if err := validator.ValidateProjectStep("name", &models.ProjectData{Name: name}); err != nil {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, name)
+		}
+	}
+
+	if len(candidates) < minNameCandidates {
+		return &models.AssistantResponse{
+			Message:        t(context, "name.none"),
+			ProjectContext: *context,
+		}, nil
+	}
+
+	context.NameCandidates = candidates
+
+	var message strings.Builder
+	message.WriteString(t(context, "name.candidates_header"))
+	for i, name := range candidates {
+		message.WriteString(fmt.Sprintf("%d. %s\n", i+1, name))
+	}
+	message.WriteString(t(context, "name.candidates_footer"))
+
+	return &models.AssistantResponse{
+		Message:        message.String(),
+		ProjectContext: *context,
+	}, nil
+}
+
+// nameRequestBrief возвращает запрос на генерацию названия, если в нем кроме самой просьбы
+// есть хотя бы minNameBriefWords слова о проекте, иначе пустую строку
+func nameRequestBrief(message string) string {
+	words := 0
+	for _, word := range wordRegex.FindAllString(strings.ToLower(message), -1) {
+		if !nameRequestWordRegex.MatchString(word) {
+			words++
+		}
+	}
+	if words < minNameBriefWords {
+		return ""
+	}
+	return strings.TrimSpace(message)
+}
+
+// requestNameCandidates запрашивает у Mistral список названий, по одному в строке
+func (pa *ProjectAssistant) requestNameCandidates(context *models.ProjectCreationContext, source string, exclude []string) ([]string, error) {
+	request := fmt.Sprintf("Придумай %d вариантов названия проекта.", maxNameCandidates)
+	if source != "" {
+		request += fmt.Sprintf(" Информация о проекте: %s", source)
+	}
+	if len(exclude) > 0 {
+		request += fmt.Sprintf(" Не повторяй эти варианты: %s.", strings.Join(exclude, "; "))
+	}
+
+	messages := []models.AssistantMessage{
+		{
+			Role: "system",
+			Content: withAnswerLanguage(context, fmt.Sprintf(`Ты - специалист по неймингу проектов. Предлагай короткие, информативные названия.
+Требования к каждому названию:
+- от %d до %d символов
+- только буквы, цифры, пробелы, тире и подчеркивания, без кавычек и других знаков препинания
+Выведи только названия, каждое с новой строки, без пояснений.`, validator.MinNameLength, validator.MaxNameLength)),
+		},
+		{
+			Role:    "user",
+			Content: request,
+		},
+	}
+
+	response, err := pa.SendMistralRequest(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseNameCandidates(response), nil
+}
+
+// parseNameCandidates извлекает названия из ответа модели, убирая нумерацию и кавычки
+func parseNameCandidates(response string) []string {
+	var names []string
+	for _, line := range strings.Split(response, "\n") {
+		line = candidatePrefixRegex.ReplaceAllString(line, "")
+		line = strings.Trim(strings.TrimSpace(line), `"'«»*`)
+		if line != "" {
+			names = append(names, strings.TrimSpace(line))
+		}
+	}
+	return names
+}
@@ -0,0 +1,33 @@
+package service
+
+import "testing"
+
+func TestNameRequestBrief(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    bool
+	}{
+		{"только просьба", "придумай название", false},
+		{"просьба с вежливостью", "Придумай, пожалуйста, какое-нибудь красивое название для проекта", false},
+		{"просьба с одним словом о проекте", "предложи варианты названия для CRM", false},
+		{"просьба с описанием", "придумай название для мобильного приложения доставки", true},
+		{"проектор не слово просьбы", "придумай название проектор кинотеатр", true},
+		{"only the request", "suggest a good name for my project", false},
+		{"generate with options", "generate some project name options please", false},
+		{"app", "come up with a name for an analytics app", true},
+		{"user", "help me name a user forum", true},
+		{"media", "suggest names for a media update service", true},
+		{"theme", "propose a name for a theme store", true},
+		{"someday", "name ideas: someday planner", true},
+		{"projector", "generate a name for a projector rental", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nameRequestBrief(tt.message) != ""; got != tt.want {
+				t.Errorf("nameRequestBrief(%q) returned brief = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
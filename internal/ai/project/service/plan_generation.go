@@ -0,0 +1,134 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+// maxPlanAttempts ограничивает количество запросов к Mistral за одну генерацию плана.
+// Если план не прошел проверку, модель получает описание ошибки и пробует еще раз.
+const maxPlanAttempts = 2
+
+const planSystemPrompt = `Ты - опытный менеджер проектов. Разбей проект на этапы и задачи.
+Верни только JSON-объект следующего вида:
+{"milestones": [{"title": "...", "description": "...", "start_date": "ДД.ММ.ГГГГ", "due_date": "ДД.ММ.ГГГГ",
+  "tasks": [{"title": "...", "estimate_days": 3, "suggested_role": "...", "start_date": "ДД.ММ.ГГГГ", "due_date": "ДД.ММ.ГГГГ"}]}]}
+Требования:
+- от 2 до 6 этапов, в каждом от 1 до 6 задач
+- этапы идут по порядку, все даты не раньше сегодняшней и не позже дедлайна проекта
+- сроки задачи находятся внутри сроков ее этапа
+- estimate_days - оценка трудоемкости в рабочих днях, положительное число
+- suggested_role - роль исполнителя, например "Дизайнер" или "Backend-разработчик"`
+
+// GeneratePlan запрашивает у Mistral план этапов и задач проекта и проверяет его.
+// Для плана нужны описание и корректный дедлайн.
+func (pa *ProjectAssistant) GeneratePlan(locale i18n.Locale, data *models.ProjectData) (*models.ProjectPlan, error) {
+	for _, field := range []string{"description", "deadline"} {
+		if err := validator.ValidateProjectStep(field, data); err != nil {
+			return nil, err
+		}
+	}
+
+	context := &models.ProjectCreationContext{Locale: string(locale)}
+	now := time.Now()
+	messages := []models.AssistantMessage{
+		{
+			Role:    "system",
+			Content: withAnswerLanguage(context, planSystemPrompt),
+		},
+		{
+			Role:    "user",
+			Content: planRequest(data, now),
+		},
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxPlanAttempts; attempt++ {
+		response, err := pa.SendMistralJSONRequest(messages)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при генерации плана: %w", err)
+		}
+
+		plan, err := parsePlan(response)
+		if err == nil {
+			err = validator.ValidatePlan(plan, data.Deadline, now)
+		}
+		if err == nil {
+			return plan, nil
+		}
+
+		lastErr = err
+		messages = append(messages,
+			models.AssistantMessage{Role: "assistant", Content: response},
+			models.AssistantMessage{Role: "user", Content: fmt.Sprintf("План не прошел проверку: %s. Исправь его и верни JSON целиком.", validator.Message(err, i18n.RU))},
+		)
+	}
+
+	return nil, lastErr
+}
+
+// planRequest описывает проект для модели: сроки, описание и текущую команду
+func planRequest(data *models.ProjectData, now time.Time) string {
+	var request strings.Builder
+	request.WriteString(fmt.Sprintf("Сегодня: %s. Дедлайн проекта: %s.\n", now.Format("02.01.2006"), data.Deadline))
+	if data.Name != "" {
+		request.WriteString(fmt.Sprintf("Название: %s\n", data.Name))
+	}
+	request.WriteString(fmt.Sprintf("Описание: %s\n", data.Description))
+	if data.Priority != "" {
+		request.WriteString(fmt.Sprintf("Приоритет: %s\n", data.Priority))
+	}
+	if len(data.Team) > 0 {
+		members := make([]string, 0, len(data.Team))
+		for _, member := range data.Team {
+			members = append(members, fmt.Sprintf("%s %s (%s)", member.Name, member.Lastname, member.Role))
+		}
+		request.WriteString(fmt.Sprintf("Команда: %s\n", strings.Join(members, ", ")))
+	}
+	return request.String()
+}
+
+// parsePlan разбирает ответ модели; ошибка разбора считается ошибкой проверки, чтобы модель
+// получила еще одну попытку
+func parsePlan(response string) (*models.ProjectPlan, error) {
+	var plan models.ProjectPlan
+	if err := json.Unmarshal([]byte(response), &plan); err != nil {
+		return nil, validator.NewError("plan_unparsable")
+	}
+	return &plan, nil
+}
+
+// handlePlanGeneration составляет план на шаге подтверждения и показывает обновленную сводку
+func (pa *ProjectAssistant) handlePlanGeneration(context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	plan, err := pa.GeneratePlan(localeOf(context), context.ProjectData)
+	var validationErr *validator.Error
+	if errors.As(err, &validationErr) {
+		return &models.AssistantResponse{
+			Message:        t(context, "plan.failed", validator.Message(err, localeOf(context))),
+			ProjectContext: *context,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	context.ProjectData.Plan = plan
+	response := pa.confirmationResponse(context)
+	response.Message = t(context, "plan.generated") + "\n\n" + response.Message
+	return response, nil
+}
+
+// handlePlanRemoval убирает план из данных проекта
+func (pa *ProjectAssistant) handlePlanRemoval(context *models.ProjectCreationContext) *models.AssistantResponse {
+	context.ProjectData.Plan = nil
+	response := pa.confirmationResponse(context)
+	response.Message = t(context, "plan.removed") + "\n\n" + response.Message
+	return response
+}
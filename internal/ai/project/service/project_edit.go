@@ -0,0 +1,100 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/prompts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+// StartEdit начинает диалог редактирования существующего проекта. Диалог сразу переходит
+// к подтверждению: пользователь правит отдельные поля теми же шагами мастера, а перед
+// сохранением видит изменения по сравнению с исходными данными.
+func (pa *ProjectAssistant) StartEdit(projectID string, project *models.ProjectData, locale i18n.Locale) *models.AssistantResponse {
+	context := pa.newContext(locale)
+	context.ProjectID = projectID
+	context.ProjectData = project
+	context.Original = project.Clone()
+	context.CurrentStep = "confirmation"
+	context.ValidationState = validator.ValidateProjectDataIn(localeOf(context), context.ProjectData)
+
+	return pa.withHints(&models.AssistantResponse{
+		Message: t(context, "edit.started", project.Name,
+			prompts.GetProjectDataSummary(localeOf(context), project), prompts.Get(localeOf(context), "edit_choice")),
+		ProjectContext: *context,
+	})
+}
+
+// handleEditConfirmation сохраняет изменения проекта после подтверждения. Проверяются только
+// измененные поля: исходные данные проекта могли устареть (например, дедлайн уже прошел),
+// и это не должно мешать исправить другое поле.
+func (pa *ProjectAssistant) handleEditConfirmation(context *models.ProjectCreationContext) *models.AssistantResponse {
+	changed := models.ChangedFields(context.Original, context.ProjectData)
+	if len(changed) == 0 {
+		return &models.AssistantResponse{
+			Message:        t(context, "edit.no_changes", prompts.Get(localeOf(context), "edit_choice")),
+			ProjectContext: *context,
+		}
+	}
+
+	validationState := validator.ValidateProjectDataIn(localeOf(context), context.ProjectData)
+	var errorMessages []string
+	fieldErrors := make(map[string]string)
+	for path, message := range validationState.Errors {
+		if containsField(changed, rootField(path)) {
+			errorMessages = append(errorMessages, "- "+path+": "+message)
+			fieldErrors[path] = message
+		}
+	}
+	if len(errorMessages) > 0 {
+		return &models.AssistantResponse{
+			Message:        t(context, "assistant.validation_failed", strings.Join(errorMessages, "\n")),
+			ProjectContext: *context,
+			FieldErrors:    fieldErrors,
+		}
+	}
+
+	return &models.AssistantResponse{
+		Message:         t(context, "assistant.updating"),
+		ProjectContext:  *context,
+		SuggestedAction: "update_project",
+	}
+}
+
+// resetEdit отменяет все изменения и возвращает исходные данные проекта
+func (pa *ProjectAssistant) resetEdit(context *models.ProjectCreationContext) *models.AssistantResponse {
+	context.ProjectData = context.Original.Clone()
+	context.Risks = nil
+	response := pa.confirmationResponse(context)
+	response.Message = t(context, "edit.reset", response.Message)
+	return response
+}
+
+// editConfirmationPrompt возвращает список изменений проекта для подтверждения
+func (pa *ProjectAssistant) editConfirmationPrompt(context *models.ProjectCreationContext) string {
+	locale := localeOf(context)
+	changed := models.ChangedFields(context.Original, context.ProjectData)
+	if len(changed) == 0 {
+		return t(context, "edit.no_changes", prompts.Get(locale, "edit_choice"))
+	}
+	return prompts.GetEditConfirmationPrompt(locale, context.Original, context.ProjectData, changed)
+}
+
+// rootField возвращает поле верхнего уровня из пути ошибки, например "team" для "team[0].email"
+func rootField(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"strconv"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/prompts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
+)
+
+// withHints дополняет ответ описанием текущего шага и готовыми вариантами ответа,
+// чтобы клиент мог показать подходящие виджеты вместо разбора текста сообщения
+func (pa *ProjectAssistant) withHints(response *models.AssistantResponse) *models.AssistantResponse {
+	context := &response.ProjectContext
+	step, ok := pa.flow.Step(context.CurrentStep)
+	if !ok || response.SuggestedAction != "" {
+		return response
+	}
+
+	index, total := pa.flow.Position(step.ID)
+	response.Step = &models.StepInfo{
+		ID:        step.ID,
+		Field:     step.Field,
+		InputType: step.Input,
+		Index:     index,
+		Total:     total,
+		Optional:  step.Optional,
+		Options:   fieldOptions(localeOf(context), step.Field),
+	}
+	response.QuickReplies = pa.quickReplies(context, step)
+	return response
+}
+
+// quickReplies подбирает варианты ответа для состояния диалога
+func (pa *ProjectAssistant) quickReplies(context *models.ProjectCreationContext, step *wizard.Step) []models.Choice {
+	// Предложение ассистента ждет ответа "да" или "нет" на любом шаге
+	if context.Suggestion != nil {
+		return []models.Choice{reply(context, "yes"), reply(context, "no")}
+	}
+
+	var replies []models.Choice
+	switch {
+	case step.Handler == "confirmation":
+		return confirmationReplies(context)
+	case step.Field == "name" && len(context.NameCandidates) > 0:
+		for i, candidate := range context.NameCandidates {
+			replies = append(replies, models.Choice{Label: candidate, Value: strconv.Itoa(i + 1)})
+		}
+		replies = append(replies, reply(context, "more"))
+	case step.Field == "team":
+		replies = append(replies, reply(context, "done"), reply(context, "list"))
+		return replies
+	case step.Input == wizard.InputEnum:
+		replies = fieldOptions(localeOf(context), step.Field)
+	}
+
+	if step.Optional {
+		replies = append(replies, reply(context, "skip"))
+	}
+	return replies
+}
+
+// confirmationReplies возвращает варианты ответа на шаге подтверждения
+func confirmationReplies(context *models.ProjectCreationContext) []models.Choice {
+	if len(context.Duplicates) > 0 {
+		return duplicateReplies(context)
+	}
+	if context.IsEdit() {
+		return []models.Choice{reply(context, "save"), reply(context, "edit"), reply(context, "discard")}
+	}
+
+	replies := []models.Choice{reply(context, "create"), reply(context, "edit")}
+	if context.ProjectData.Plan == nil {
+		replies = append(replies, reply(context, "plan"))
+	} else {
+		replies = append(replies, reply(context, "remove_plan"))
+	}
+	return append(replies, reply(context, "risks"))
+}
+
+// fieldOptions возвращает допустимые значения поля с названиями на языке пользователя
+func fieldOptions(locale i18n.Locale, field string) []models.Choice {
+	var codes []string
+	var format func(i18n.Locale, string) string
+	switch field {
+	case "priority":
+		codes, format = validator.Priorities, prompts.FormatPriority
+	case "status":
+		codes, format = validator.ProjectStatuses, prompts.FormatStatus
+	case "confidentiality":
+		codes, format = validator.ConfidentialityLevels, prompts.FormatConfidentiality
+	case "team":
+		codes, format = validator.Roles, prompts.FormatRole
+	default:
+		return nil
+	}
+
+	options := make([]models.Choice, 0, len(codes))
+	for _, code := range codes {
+		options = append(options, models.Choice{Label: format(locale, code), Value: code})
+	}
+	return options
+}
+
+// reply возвращает готовый ответ: подпись кнопки и сообщение, которое понимает ассистент
+func reply(context *models.ProjectCreationContext, name string) models.Choice {
+	return models.Choice{Label: t(context, "reply."+name), Value: t(context, "reply_value."+name)}
+}
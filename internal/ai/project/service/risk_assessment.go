@@ -0,0 +1,151 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/calendar"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+)
+
+const (
+	// maxRiskAttempts ограничивает количество запросов к Mistral за одну оценку рисков
+	maxRiskAttempts = 2
+	// TopRisksLimit - сколько самых серьезных рисков показывать на шаге подтверждения
+	TopRisksLimit = 3
+)
+
+const riskSystemPrompt = `Ты - эксперт по управлению рисками проектов. Составь реестр рисков проекта.
+Для каждого риска укажи категорию (schedule, budget, resources, scope, technical, external),
+вероятность и влияние (low, medium, high) и конкретные меры снижения риска.
+Опирайся на сроки, размер команды и бюджет проекта. Укажи от 3 до 8 рисков.`
+
+// riskSchema описывает ответ модели для структурированного вывода Mistral
+var riskSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"risks": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"category":   map[string]interface{}{"type": "string", "enum": validator.RiskCategories},
+					"title":      map[string]interface{}{"type": "string"},
+					"likelihood": map[string]interface{}{"type": "string", "enum": validator.RiskLevels},
+					"impact":     map[string]interface{}{"type": "string", "enum": validator.RiskLevels},
+					"mitigation": map[string]interface{}{"type": "string"},
+				},
+				"required":             []string{"category", "title", "likelihood", "impact", "mitigation"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"risks"},
+	"additionalProperties": false,
+}
+
+// AssessRisks запрашивает у Mistral реестр рисков проекта и проверяет его
+func (pa *ProjectAssistant) AssessRisks(locale i18n.Locale, data *models.ProjectData) ([]models.Risk, error) {
+	if err := validator.ValidateProjectStep("description", data); err != nil {
+		return nil, err
+	}
+
+	context := &models.ProjectCreationContext{Locale: string(locale)}
+	messages := []models.AssistantMessage{
+		{
+			Role:    "system",
+			Content: withAnswerLanguage(context, riskSystemPrompt),
+		},
+		{
+			Role:    "user",
+			Content: riskRequest(data, time.Now()),
+		},
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxRiskAttempts; attempt++ {
+		response, err := pa.SendMistralSchemaRequest(messages, "risk_register", riskSchema)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при оценке рисков: %w", err)
+		}
+
+		var register struct {
+			Risks []models.Risk `json:"risks"`
+		}
+		if err := json.Unmarshal([]byte(response), &register); err != nil {
+			lastErr = validator.NewError("risk_unparsable")
+			continue
+		}
+		if err := validator.ValidateRisks(register.Risks); err != nil {
+			lastErr = err
+			messages = append(messages,
+				models.AssistantMessage{Role: "assistant", Content: response},
+				models.AssistantMessage{Role: "user", Content: fmt.Sprintf("Реестр не прошел проверку: %s. Исправь его и верни JSON целиком.", validator.Message(err, i18n.RU))},
+			)
+			continue
+		}
+		return register.Risks, nil
+	}
+
+	return nil, lastErr
+}
+
+// riskRequest описывает для модели параметры проекта, от которых зависят риски
+func riskRequest(data *models.ProjectData, now time.Time) string {
+	var request strings.Builder
+	if data.Name != "" {
+		request.WriteString(fmt.Sprintf("Название: %s\n", data.Name))
+	}
+	request.WriteString(fmt.Sprintf("Описание: %s\n", data.Description))
+	if deadline, err := time.Parse("02.01.2006", data.Deadline); err == nil {
+		days := calendar.Default().WorkingDaysBetween(now, deadline)
+		request.WriteString(fmt.Sprintf("Дедлайн: %s, осталось рабочих дней: %d\n", data.Deadline, days))
+	} else {
+		request.WriteString("Дедлайн не указан\n")
+	}
+	if data.Priority != "" {
+		request.WriteString(fmt.Sprintf("Приоритет: %s\n", data.Priority))
+	}
+	request.WriteString(fmt.Sprintf("Размер команды: %d\n", len(data.Team)))
+	if data.Budget != "" {
+		request.WriteString(fmt.Sprintf("Бюджет: %s\n", data.Budget))
+	} else {
+		request.WriteString("Бюджет не указан\n")
+	}
+	if data.Spent != "" {
+		request.WriteString(fmt.Sprintf("Уже потрачено: %s\n", data.Spent))
+	}
+	if data.Plan != nil {
+		request.WriteString(fmt.Sprintf("Этапов в плане: %d\n", len(data.Plan.Milestones)))
+	}
+	if effort := validator.EffortDays(data); effort > 0 {
+		request.WriteString(fmt.Sprintf("Оценка трудоемкости: %s чел.-дн.\n", strconv.FormatFloat(effort, 'f', -1, 64)))
+	}
+	return request.String()
+}
+
+// handleRiskAssessment составляет реестр рисков на шаге подтверждения и сохраняет его в сессии мастера
+func (pa *ProjectAssistant) handleRiskAssessment(context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	risks, err := pa.AssessRisks(localeOf(context), context.ProjectData)
+	var validationErr *validator.Error
+	if errors.As(err, &validationErr) {
+		return &models.AssistantResponse{
+			Message:        t(context, "risk.failed", validator.Message(err, localeOf(context))),
+			ProjectContext: *context,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	context.Risks = risks
+	response := pa.confirmationResponse(context)
+	response.Message = t(context, "risk.assessed", len(risks)) + "\n\n" + response.Message
+	return response, nil
+}
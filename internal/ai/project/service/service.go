@@ -2,236 +2,527 @@ package service
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"reflect"
 	"strings"
+	"time"
 
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
 	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
 	"github.com/Jamolkhon5/mistral/internal/ai/project/prompts"
 	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
 )
 
+// maxHistoryLength ограничивает количество шагов, на которые можно вернуться назад
+const maxHistoryLength = 20
+
 type ProjectAssistant struct {
 	mistralApiKey string
 	modelName     string
+	intents       *IntentAnalyzer
+	flow          *wizard.Definition
+	parsers       map[string]parserFunc
+	handlers      map[string]stepHandler
 }
 
-func NewProjectAssistant(apiKey, modelName string) *ProjectAssistant {
-	return &ProjectAssistant{
+// NewProjectAssistant создает ассистента, который ведет диалог по шагам из описания мастера
+func NewProjectAssistant(apiKey, modelName string, flow *wizard.Definition) (*ProjectAssistant, error) {
+	pa := &ProjectAssistant{
 		mistralApiKey: apiKey,
 		modelName:     modelName,
+		intents:       NewIntentAnalyzer(),
+		flow:          flow,
+	}
+	pa.parsers = pa.defaultParsers()
+	pa.handlers = pa.defaultHandlers()
+
+	if err := flow.Check(pa); err != nil {
+		return nil, fmt.Errorf("некорректное описание мастера %s: %w", flow.Name, err)
+	}
+
+	return pa, nil
+}
+
+// HandleMessage обрабатывает сообщение пользователя и возвращает ответ ассистента.
+// Если язык не указан, используется язык, сохраненный в контексте диалога.
+func (pa *ProjectAssistant) HandleMessage(userMessage string, context *models.ProjectCreationContext, locale i18n.Locale) (*models.AssistantResponse, error) {
+	response, err := pa.handleMessage(userMessage, context, locale)
+	if err != nil {
+		return nil, err
 	}
+	return pa.withHints(response), nil
 }
 
-// HandleMessage обрабатывает сообщение пользователя и возвращает ответ ассистента
-func (pa *ProjectAssistant) HandleMessage(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+func (pa *ProjectAssistant) handleMessage(userMessage string, context *models.ProjectCreationContext, locale i18n.Locale) (*models.AssistantResponse, error) {
 	// Если контекст не определен или пустой, инициализируем новый
 	if context == nil || context.CurrentStep == "" {
-		context = &models.ProjectCreationContext{
-			CurrentStep: "name",
-			ProjectData: &models.ProjectData{
-				Status:          "В_ПРОЦЕССЕ",
-				Priority:        "СРЕДНИЙ",
-				Budget:          "0",
-				Spent:           "0",
-				Confidentiality: "Только для участников",
-				Progress:        0,
-				Team:            make([]models.TeamMember, 0),
-			},
-		}
+		context = pa.newContext(locale)
 		return &models.AssistantResponse{
-			Message:        prompts.WelcomeMessage,
+			Message:        prompts.Get(localeOf(context), "welcome"),
 			ProjectContext: *context,
 		}, nil
 	}
+	setLocale(context, locale)
 
-	// Обработка запроса на генерацию описания
-	if strings.Contains(strings.ToLower(userMessage), "сгенерируй описание") {
-		return pa.handleDescriptionGeneration(userMessage, context)
+	// Возврат к предыдущему шагу
+	if action := pa.intents.DetectNavigation(userMessage); action != "" {
+		return pa.goBack(context, "")
 	}
 
-	// Добавляем логирование для отладки
-	log.Printf("Обработка шага: %s с сообщением: %s", context.CurrentStep, userMessage)
+	// Запоминаем состояние до обработки сообщения, чтобы к нему можно было вернуться
+	snapshot := models.StepSnapshot{
+		Step:        context.CurrentStep,
+		ReturnStep:  context.ReturnStep,
+		ProjectData: context.ProjectData.Clone(),
+	}
 
-	// Обработка текущего шага
-	switch context.CurrentStep {
-	case "name":
-		return pa.handleNameStep(userMessage, context)
-	case "description":
-		return pa.handleDescriptionStep(userMessage, context)
-	case "deadline":
-		return pa.handleDeadlineStep(userMessage, context)
-	case "priority":
-		return pa.handlePriorityStep(userMessage, context)
-	case "team":
-		return pa.handleTeamStep(userMessage, context)
-	case "confirmation":
-		return pa.handleConfirmationStep(userMessage, context)
-	default:
-		return nil, fmt.Errorf("неизвестный шаг: %s", context.CurrentStep)
+	response, err := pa.handleStep(userMessage, context)
+	if err != nil {
+		return nil, err
+	}
+
+	// После изменения данных проект должен создаваться с новым ключом идемпотентности
+	if !reflect.DeepEqual(response.ProjectContext.ProjectData, snapshot.ProjectData) {
+		response.ProjectContext.CreationKey = ""
 	}
+
+	recordHistory(response, snapshot)
+	return response, nil
 }
 
-func (pa *ProjectAssistant) handleNameStep(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
-	context.ProjectData.Name = strings.TrimSpace(userMessage)
+// StartFromTemplate начинает новый диалог с данными проекта, предзаполненными из шаблона.
+// Шаги заполненных полей пропускаются, изменить их можно на шаге подтверждения.
+func (pa *ProjectAssistant) StartFromTemplate(template *models.ProjectTemplate, locale i18n.Locale) *models.AssistantResponse {
+	context := pa.newContext(locale)
+	context.TemplateID = template.ID
+	context.Prefilled = template.Apply(context.ProjectData, time.Now())
+	context.SuggestedRoles = template.TeamRoles
+
+	fields := make([]string, 0, len(context.Prefilled))
+	for _, field := range context.Prefilled {
+		fields = append(fields, prompts.FieldLabel(localeOf(context), field))
+	}
 
-	if err := validator.ValidateProjectStep("name", context.ProjectData); err != nil {
-		return &models.AssistantResponse{
-			Message:        fmt.Sprintf("❌ %s\n\nПожалуйста, введите корректное название проекта.", err.Error()),
-			ProjectContext: *context,
-		}, nil
+	message := t(context, "template.applied", template.Name)
+	if len(fields) > 0 {
+		message += " " + t(context, "template.prefilled", strings.Join(fields, ", "))
 	}
 
-	context.CurrentStep = "description"
-	return &models.AssistantResponse{
-		Message:        prompts.DescriptionPrompt,
+	return pa.withHints(&models.AssistantResponse{
+		Message:        message + "\n\n" + pa.stepPrompt(context.CurrentStep, context),
 		ProjectContext: *context,
-	}, nil
+	})
 }
 
-func (pa *ProjectAssistant) handleDescriptionStep(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
-	context.ProjectData.Description = strings.TrimSpace(userMessage)
+// Resume продолжает сохраненную сессию мастера: повторяет подсказку шага, на котором
+// пользователь остановился
+func (pa *ProjectAssistant) Resume(context *models.ProjectCreationContext, locale i18n.Locale) *models.AssistantResponse {
+	setLocale(context, locale)
+	if context.ProjectData == nil {
+		context.ProjectData = pa.newContext(locale).ProjectData
+	}
+	if _, ok := pa.flow.Step(context.CurrentStep); !ok {
+		context.CurrentStep = pa.flow.Start
+	}
 
-	if err := validator.ValidateProjectStep("description", context.ProjectData); err != nil {
-		return &models.AssistantResponse{
-			Message:        fmt.Sprintf("❌ %s\n\nПожалуйста, введите корректное описание проекта.", err.Error()),
-			ProjectContext: *context,
-		}, nil
+	message := pa.stepPrompt(context.CurrentStep, context)
+	if context.CurrentStep == "confirmation" {
+		message = pa.confirmationPrompt(context)
+	}
+	key := "draft.resumed"
+	if context.IsEdit() {
+		key = "draft.resumed_edit"
 	}
+	return pa.withHints(&models.AssistantResponse{
+		Message:        t(context, key, message),
+		ProjectContext: *context,
+	})
+}
 
-	context.CurrentStep = "deadline"
-	return &models.AssistantResponse{
-		Message:        prompts.DeadlinePrompt,
+// TemplateNotFound сообщает, что запрошенный шаблон не найден, и повторяет подсказку текущего шага
+func (pa *ProjectAssistant) TemplateNotFound(context *models.ProjectCreationContext, locale i18n.Locale, name string) *models.AssistantResponse {
+	if context == nil || context.CurrentStep == "" {
+		context = pa.newContext(locale)
+	}
+	setLocale(context, locale)
+
+	return pa.withHints(&models.AssistantResponse{
+		Message:        t(context, "template.not_found", name) + "\n\n" + pa.stepPrompt(context.CurrentStep, context),
 		ProjectContext: *context,
-	}, nil
+	})
 }
 
-func (pa *ProjectAssistant) handleDeadlineStep(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
-	context.ProjectData.Deadline = strings.TrimSpace(userMessage)
+// CanApplyTemplate сообщает, можно ли начать диалог по шаблону: диалог еще не начат
+// или пользователь не ввел ничего, кроме первого сообщения
+func (pa *ProjectAssistant) CanApplyTemplate(context *models.ProjectCreationContext) bool {
+	if context == nil || context.CurrentStep == "" {
+		return true
+	}
+	return context.CurrentStep == pa.flow.Start && len(context.History) == 0 && context.TemplateID == ""
+}
+
+// DetectTemplateRequest распознает просьбу создать проект по шаблону и возвращает название шаблона
+func (pa *ProjectAssistant) DetectTemplateRequest(message string) (string, bool) {
+	return pa.intents.DetectTemplateRequest(message)
+}
 
-	if err := validator.ValidateProjectStep("deadline", context.ProjectData); err != nil {
+// newContext создает контекст нового диалога со значениями полей по умолчанию
+func (pa *ProjectAssistant) newContext(locale i18n.Locale) *models.ProjectCreationContext {
+	return &models.ProjectCreationContext{
+		CurrentStep: pa.flow.Start,
+		Locale:      string(i18n.Resolve(locale)),
+		ProjectData: &models.ProjectData{
+			Status:          "В_ПРОЦЕССЕ",
+			Priority:        "СРЕДНИЙ",
+			Budget:          "0",
+			Spent:           "0",
+			Confidentiality: "Только для участников",
+			Progress:        0,
+			Team:            make([]models.TeamMember, 0),
+		},
+	}
+}
+
+// GoBack возвращает диалог на предыдущий шаг и восстанавливает значения полей,
+// которые были до перехода
+func (pa *ProjectAssistant) GoBack(context *models.ProjectCreationContext, locale i18n.Locale) (*models.AssistantResponse, error) {
+	response, err := pa.goBack(context, locale)
+	if err != nil {
+		return nil, err
+	}
+	return pa.withHints(response), nil
+}
+
+func (pa *ProjectAssistant) goBack(context *models.ProjectCreationContext, locale i18n.Locale) (*models.AssistantResponse, error) {
+	if context == nil || context.CurrentStep == "" {
+		return pa.handleMessage("", nil, locale)
+	}
+	setLocale(context, locale)
+
+	if len(context.History) == 0 {
 		return &models.AssistantResponse{
-			Message:        fmt.Sprintf("❌ %s\n\nПожалуйста, введите корректную дату в формате ДД.ММ.ГГГГ.", err.Error()),
+			Message:        t(context, "assistant.first_step", pa.stepPrompt(context.CurrentStep, context)),
 			ProjectContext: *context,
 		}, nil
 	}
 
-	context.CurrentStep = "priority"
+	last := context.History[len(context.History)-1]
+	context.History = context.History[:len(context.History)-1]
+	context.Suggestion = nil
+	context.CreationKey = ""
+	context.CurrentStep = last.Step
+	context.ReturnStep = last.ReturnStep
+	if last.ProjectData != nil {
+		context.ProjectData = last.ProjectData
+	}
+
+	if context.CurrentStep == "confirmation" {
+		return pa.confirmationResponse(context), nil
+	}
+
 	return &models.AssistantResponse{
-		Message:        prompts.PriorityPrompt,
+		Message:        t(context, "assistant.went_back", pa.stepPrompt(context.CurrentStep, context)),
 		ProjectContext: *context,
 	}, nil
 }
 
-func (pa *ProjectAssistant) handlePriorityStep(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
-	priority := strings.ToUpper(strings.TrimSpace(userMessage))
-	switch priority {
-	case "ВЫСОКИЙ", "СРЕДНИЙ", "НИЗКИЙ":
-		context.ProjectData.Priority = priority
-	default:
+// recordHistory добавляет снимок состояния в историю, если сообщение перевело диалог на другой шаг
+// или успешно изменило данные на шагах команды и подтверждения
+func recordHistory(response *models.AssistantResponse, snapshot models.StepSnapshot) {
+	ctx := &response.ProjectContext
+	stepChanged := ctx.CurrentStep != snapshot.Step || ctx.ReturnStep != snapshot.ReturnStep
+	dataChanged := !reflect.DeepEqual(ctx.ProjectData, snapshot.ProjectData)
+	committedEdit := dataChanged && (ctx.CurrentStep == "confirmation" || ctx.CurrentStep == "team")
+	if !stepChanged && !committedEdit {
+		return
+	}
+
+	ctx.History = append(ctx.History, snapshot)
+	if len(ctx.History) > maxHistoryLength {
+		ctx.History = ctx.History[len(ctx.History)-maxHistoryLength:]
+	}
+}
+
+func (pa *ProjectAssistant) handleStep(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	// Ответ на предложение ассистента, ожидающее решения пользователя
+	if context.Suggestion != nil {
+		return pa.handleSuggestionReply(userMessage, context)
+	}
+
+	// Обработка запроса на генерацию описания
+	if pa.intents.IsDescriptionGenerationRequest(userMessage) {
+		return pa.handleDescriptionGeneration(userMessage, context)
+	}
+
+	// Вопросы о мастере и сообщения не по теме не меняют данные и оставляют пользователя на шаге
+	command := pa.isAssistantCommand(userMessage, context)
+	classified := false
+	if !command && pa.intents.IsQuestion(userMessage) {
+		classified = true
+		if response := pa.handleHelp(userMessage, context); response != nil {
+			return response, nil
+		}
+	}
+
+	// Добавляем логирование для отладки
+	log.Printf("Обработка шага: %s с сообщением: %s", context.CurrentStep, userMessage)
+
+	// Обработка текущего шага по описанию мастера
+	step, ok := pa.flow.Step(context.CurrentStep)
+	if !ok {
+		return nil, fmt.Errorf("неизвестный шаг: %s", context.CurrentStep)
+	}
+
+	response, err := pa.runStep(step, userMessage, context)
+	if err != nil || response == nil || !response.Unparsed || command || classified {
+		return response, err
+	}
+
+	// Сообщение, которое шаг не смог разобрать, может быть вопросом без вопросительного знака
+	// или просьбой не по теме. Если классификатор так не считает, показываем ошибку шага.
+	if help := pa.handleHelp(userMessage, context); help != nil {
+		return help, nil
+	}
+	return response, nil
+}
+
+func (pa *ProjectAssistant) handleNameStep(userMessage string, context *models.ProjectCreationContext, step *wizard.Step) (*models.AssistantResponse, error) {
+	// Выбор из ранее сгенерированных вариантов
+	if len(context.NameCandidates) > 0 {
+		if response, err := pa.handleNameCandidates(userMessage, context, step); response != nil || err != nil {
+			return response, err
+		}
+	}
+
+	// Запрос на генерацию названия. "Ещё" без показанных вариантов тоже просит новые варианты,
+	// а не становится названием проекта.
+	if intent := pa.intents.AnalyzeMessage(userMessage); intent.Type == "generate_name" {
+		return pa.handleNameGeneration(userMessage, context)
+	}
+	if i18n.IsKeyword(localeOf(context), i18n.KeywordMore, userMessage) {
+		return pa.handleNameGeneration("", context)
+	}
+
+	// Ответ на просьбу коротко описать проект перед генерацией названия
+	if context.AwaitNameBrief {
+		return pa.handleNameBrief(userMessage, context)
+	}
+
+	context.NameCandidates = nil
+	return pa.handleFieldStep(step, userMessage, context)
+}
+
+func (pa *ProjectAssistant) handleConfirmationStep(userMessage string, context *models.ProjectCreationContext, step *wizard.Step) (*models.AssistantResponse, error) {
+	locale := localeOf(context)
+
+	// Ответ на предложение открыть похожий проект или переименовать новый
+	if len(context.Duplicates) > 0 {
+		if response, err := pa.handleDuplicateChoice(userMessage, context); response != nil || err != nil {
+			return response, err
+		}
+	}
+
+	if i18n.IsKeyword(locale, i18n.KeywordYes, userMessage) {
+		if context.IsEdit() {
+			return pa.handleEditConfirmation(context), nil
+		}
+		return pa.confirmCreation(context), nil
+	} else if i18n.IsKeyword(locale, i18n.KeywordNo, userMessage) {
+		return &models.AssistantResponse{
+			Message:        prompts.Get(locale, "edit_choice"),
+			ProjectContext: *context,
+		}, nil
+	} else if i18n.IsKeyword(locale, i18n.KeywordRestart, userMessage) {
+		// При редактировании "заново" означает отмену всех изменений
+		if context.IsEdit() {
+			return pa.resetEdit(context), nil
+		}
+		context.CurrentStep = pa.flow.Start
 		return &models.AssistantResponse{
-			Message:        "❌ Пожалуйста, выберите один из вариантов: высокий, средний или низкий.",
+			Message:        t(context, "assistant.restart", pa.stepPrompt(context.CurrentStep, context)),
 			ProjectContext: *context,
 		}, nil
 	}
 
-	context.CurrentStep = "team"
+	switch {
+	case pa.intents.IsPlanRemoval(userMessage):
+		return pa.handlePlanRemoval(context), nil
+	case pa.intents.IsPlanRequest(userMessage):
+		return pa.handlePlanGeneration(context)
+	case pa.intents.IsRiskRequest(userMessage):
+		return pa.handleRiskAssessment(context)
+	}
+
+	// Точечная правка отдельного поля без повторного прохождения всех шагов
+	if field, value, ok := pa.intents.DetectFieldEdit(userMessage); ok {
+		return pa.handleFieldEdit(field, value, context)
+	}
+
 	return &models.AssistantResponse{
-		Message:        prompts.TeamPrompt,
+		Message:        t(context, "assistant.confirm_hint"),
 		ProjectContext: *context,
+		Unparsed:       true,
 	}, nil
 }
 
-func (pa *ProjectAssistant) handleTeamStep(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
-	if strings.ToLower(userMessage) == "готово" {
-		context.CurrentStep = "confirmation"
-		summary := prompts.GetProjectDataSummary(context.ProjectData)
+// confirmCreation проверяет все данные проекта и, если ошибок нет, просит создать проект
+func (pa *ProjectAssistant) confirmCreation(context *models.ProjectCreationContext) *models.AssistantResponse {
+	// Финальная валидация всех данных
+	validationState := validator.ValidateProjectDataIn(localeOf(context), context.ProjectData)
+	if !validationState.IsValid {
+		errorMessages := make([]string, 0)
+		for field, err := range validationState.Errors {
+			errorMessages = append(errorMessages, fmt.Sprintf("- %s: %s", field, err))
+		}
 		return &models.AssistantResponse{
-			Message:        fmt.Sprintf(prompts.ConfirmationPrompt, summary),
+			Message:        t(context, "assistant.validation_failed", strings.Join(errorMessages, "\n")),
 			ProjectContext: *context,
-		}, nil
+			FieldErrors:    validationState.Errors,
+		}
 	}
 
-	// Логика добавления участника команды...
-	// Здесь должна быть интеграция с сервисом аутентификации для поиска пользователей
+	// Ключ сохраняется в контексте, чтобы повторное подтверждение после сбоя не создало дубликат
+	if context.CreationKey == "" {
+		context.CreationKey = newCreationKey()
+	}
 
 	return &models.AssistantResponse{
-		Message:        "Введите email следующего участника или напишите 'готово' для завершения.",
-		ProjectContext: *context,
-	}, nil
+		Message:         t(context, "assistant.creating"),
+		ProjectContext:  *context,
+		SuggestedAction: "create_project",
+	}
 }
 
-func (pa *ProjectAssistant) handleConfirmationStep(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
-	answer := strings.ToLower(strings.TrimSpace(userMessage))
-
-	if answer == "да" {
-		// Финальная валидация всех данных
-		validationState := validator.ValidateProjectData(context.ProjectData)
-		if !validationState.IsValid {
-			errorMessages := make([]string, 0)
-			for field, err := range validationState.Errors {
-				errorMessages = append(errorMessages, fmt.Sprintf("- %s: %s", field, err))
-			}
-			return &models.AssistantResponse{
-				Message:        fmt.Sprintf("❌ Обнаружены ошибки:\n%s\n\nПожалуйста, исправьте их и попробуйте снова.", strings.Join(errorMessages, "\n")),
-				ProjectContext: *context,
-			}, nil
-		}
-
+// handleFieldEdit повторно запускает шаг указанного поля и после успешной валидации
+// возвращает пользователя к подтверждению
+func (pa *ProjectAssistant) handleFieldEdit(field, value string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
+	step, ok := pa.flow.StepForField(field)
+	if !ok {
 		return &models.AssistantResponse{
-			Message:         "✅ Отлично! Создаю проект...",
-			ProjectContext:  *context,
-			SuggestedAction: "create_project",
+			Message:        prompts.Get(localeOf(context), "edit_choice"),
+			ProjectContext: *context,
 		}, nil
-	} else if answer == "нет" {
-		context.CurrentStep = "name"
+	}
+
+	context.CurrentStep = step.ID
+	context.ReturnStep = "confirmation"
+
+	// Если новое значение не указано, просим его ввести
+	if value == "" {
 		return &models.AssistantResponse{
-			Message:        "Хорошо, давайте начнем сначала. Как назовем проект?",
+			Message:        pa.stepPrompt(step.ID, context),
 			ProjectContext: *context,
 		}, nil
 	}
 
+	return pa.runStep(step, value, context)
+}
+
+// confirmationResponse переводит диалог на шаг подтверждения с актуальной сводкой данных
+func (pa *ProjectAssistant) confirmationResponse(context *models.ProjectCreationContext) *models.AssistantResponse {
+	context.CurrentStep = "confirmation"
+	message := pa.confirmationPrompt(context)
 	return &models.AssistantResponse{
-		Message:        "Пожалуйста, ответьте 'да' или 'нет'.",
+		Message:        message,
 		ProjectContext: *context,
-	}, nil
+	}
 }
 
-func (pa *ProjectAssistant) handleDescriptionGeneration(userMessage string, context *models.ProjectCreationContext) (*models.AssistantResponse, error) {
-	// Формируем промпт для Mistral API
-	messages := []models.AssistantMessage{
-		{
-			Role:    "system",
-			Content: "Ты - специалист по написанию описаний проектов. Твоя задача - создать четкое и структурированное описание проекта на основе краткой информации от пользователя.",
-		},
-		{
-			Role:    "user",
-			Content: fmt.Sprintf("Создай подробное описание проекта на основе этой информации: %s", userMessage),
-		},
+// confirmationPrompt возвращает сводку данных проекта и обновляет состояние валидации в контексте.
+// Все предупреждения выводятся под сводкой и не мешают подтвердить создание.
+func (pa *ProjectAssistant) confirmationPrompt(context *models.ProjectCreationContext) string {
+	locale := localeOf(context)
+	context.ValidationState = validator.ValidateProjectDataIn(locale, context.ProjectData)
+
+	prompt := prompts.GetConfirmationPrompt(locale, context.ProjectData)
+	if context.IsEdit() {
+		prompt = pa.editConfirmationPrompt(context)
+	}
+	var warnings []string
+	for _, warning := range validator.CheckWarnings(context.ProjectData, time.Now()) {
+		warnings = append(warnings, warning.Localize(locale))
+	}
+	if len(warnings) > 0 {
+		prompt += "\n\n" + formatWarnings(context, warnings)
 	}
+	if len(context.Risks) > 0 {
+		prompt += "\n\n" + prompts.FormatTopRisks(locale, context.Risks, TopRisksLimit)
+	}
+	return prompt
+}
 
-	// Отправляем запрос к Mistral API
-	response, err := pa.SendMistralRequest(messages)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка при генерации описания: %w", err)
+// newCreationKey создает случайный ключ идемпотентности для создания проекта
+func newCreationKey() string {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		// crypto/rand не должен возвращать ошибку; на всякий случай используем время
+		return fmt.Sprintf("%x", time.Now().UnixNano())
 	}
+	return hex.EncodeToString(key)
+}
 
-	// Сохраняем сгенерированное описание
-	context.ProjectData.Description = response
+// localeOf возвращает язык диалога, сохраненный в контексте
+func localeOf(context *models.ProjectCreationContext) i18n.Locale {
+	if context == nil {
+		return i18n.DefaultLocale
+	}
+	return i18n.Resolve(i18n.Normalize(context.Locale))
+}
 
-	return &models.AssistantResponse{
-		Message:        fmt.Sprintf("✨ Я сгенерировал следующее описание для вашего проекта:\n\n%s\n\nХотите использовать это описание? Ответьте 'да' или 'нет'.", response),
-		ProjectContext: *context,
-	}, nil
+// setLocale сохраняет в контексте язык, явно выбранный для текущего сообщения
+func setLocale(context *models.ProjectCreationContext, locale i18n.Locale) {
+	if locale != "" {
+		context.Locale = string(locale)
+	}
+}
+
+// t возвращает сообщение ассистента на языке диалога
+func t(context *models.ProjectCreationContext, key string, args ...interface{}) string {
+	return i18n.T(localeOf(context), key, args...)
+}
+
+// withAnswerLanguage дополняет системный промпт Mistral указанием языка ответа
+func withAnswerLanguage(context *models.ProjectCreationContext, systemPrompt string) string {
+	return systemPrompt + "\n" + t(context, "assistant.answer_language")
 }
 
 func (pa *ProjectAssistant) SendMistralRequest(messages []models.AssistantMessage) (string, error) {
+	return pa.sendMistralRequest(messages, nil)
+}
+
+// SendMistralJSONRequest отправляет запрос к Mistral в режиме JSON: модель обязана вернуть
+// корректный JSON-объект
+func (pa *ProjectAssistant) SendMistralJSONRequest(messages []models.AssistantMessage) (string, error) {
+	return pa.sendMistralRequest(messages, map[string]interface{}{"type": "json_object"})
+}
+
+// SendMistralSchemaRequest отправляет запрос к Mistral со структурированным выводом:
+// ответ модели соответствует переданной JSON-схеме
+func (pa *ProjectAssistant) SendMistralSchemaRequest(messages []models.AssistantMessage, name string, schema map[string]interface{}) (string, error) {
+	return pa.sendMistralRequest(messages, map[string]interface{}{
+		"type": "json_schema",
+		"json_schema": map[string]interface{}{
+			"name":   name,
+			"schema": schema,
+			"strict": true,
+		},
+	})
+}
+
+func (pa *ProjectAssistant) sendMistralRequest(messages []models.AssistantMessage, responseFormat map[string]interface{}) (string, error) {
 	requestBody := map[string]interface{}{
 		"model":    pa.modelName,
 		"messages": messages,
 	}
+	if responseFormat != nil {
+		requestBody["response_format"] = responseFormat
+	}
 
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
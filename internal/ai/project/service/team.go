@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/prompts"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/validator"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/wizard"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
+)
+
+// defaultMemberRole назначается, если роль участника не указана
+const defaultMemberRole = "READER"
+
+var (
+	teamSeparatorRegex = regexp.MustCompile(`[,;\n]+`)
+	removeWordsRegex   = regexp.MustCompile(`^(удали|убери|исключи|remove|delete)\s+`)
+
+	// errEmailNotKnown возвращается, если email не найден среди участников проектов пользователя
+	errEmailNotKnown = errors.New("email not found among project members")
+)
+
+// memberRequest описывает одного участника из сообщения пользователя
+type memberRequest struct {
+	raw    string
+	userID string
+	email  string
+	role   string
+}
+
+func (pa *ProjectAssistant) handleTeamStep(userMessage string, context *models.ProjectCreationContext, step *wizard.Step) (*models.AssistantResponse, error) {
+	message := strings.TrimSpace(userMessage)
+	lower := strings.ToLower(message)
+	locale := localeOf(context)
+
+	switch {
+	case i18n.IsKeyword(locale, i18n.KeywordDone, message):
+		return pa.advance(context, step, len(context.ProjectData.Team) == 0), nil
+	case i18n.IsKeyword(locale, i18n.KeywordList, message):
+		return pa.teamResponse(context, ""), nil
+	}
+
+	if removeWordsRegex.MatchString(lower) {
+		return pa.handleTeamRemoval(message, context), nil
+	}
+
+	requests, notes := pa.parseMemberRequests(locale, message)
+	if len(requests) == 0 {
+		response := pa.teamResponse(context, t(context, "team.no_members_found")+"\n"+strings.Join(notes, "\n"))
+		response.Unparsed = true
+		return response, nil
+	}
+
+	users := pa.prefetchUsers(requests, context.KnownMembers)
+
+	added := make([]models.TeamMember, 0, len(requests))
+	for _, req := range requests {
+		if isTeamMember(context.ProjectData.Team, req.userID, req.email) || isTeamMember(added, req.userID, req.email) {
+			notes = append(notes, t(context, "team.already_member", req.raw))
+			continue
+		}
+
+		user, err := pa.resolveUser(req, users, context.KnownMembers)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("❌ %s: %s", req.raw, describeLookupError(locale, err)))
+			continue
+		}
+
+		// Проверяем дубликаты еще раз: пользователь мог быть указан по email, а найден по ID
+		if isTeamMember(context.ProjectData.Team, user.GetId(), user.GetEmail()) || isTeamMember(added, user.GetId(), user.GetEmail()) {
+			notes = append(notes, t(context, "team.already_member", user.GetName()+" "+user.GetLastname()))
+			continue
+		}
+
+		member := models.TeamMember{
+			ID:       user.GetId(),
+			Name:     user.GetName(),
+			Lastname: user.GetLastname(),
+			Email:    user.GetEmail(),
+			Role:     req.role,
+			Photo:    user.GetPhoto(),
+		}
+		if err := validator.ValidateProjectStep("team", &models.ProjectData{Team: []models.TeamMember{member}}); err != nil {
+			notes = append(notes, fmt.Sprintf("❌ %s: %s", req.raw, validator.Message(err, locale)))
+			continue
+		}
+
+		added = append(added, member)
+		notes = append(notes, t(context, "team.added", member.Name, member.Lastname, prompts.FormatRole(locale, member.Role)))
+	}
+
+	if len(added) > 0 {
+		team := make([]models.TeamMember, 0, len(context.ProjectData.Team)+len(added))
+		team = append(team, context.ProjectData.Team...)
+		context.ProjectData.Team = append(team, added...)
+	}
+
+	return pa.teamResponse(context, strings.Join(notes, "\n")), nil
+}
+
+// handleTeamRemoval удаляет участника по email или ID
+func (pa *ProjectAssistant) handleTeamRemoval(message string, context *models.ProjectCreationContext) *models.AssistantResponse {
+	lower := strings.ToLower(message)
+	target := strings.TrimSpace(removeWordsRegex.ReplaceAllString(lower, ""))
+	if loc := removeWordsRegex.FindStringIndex(lower); loc != nil && len(lower) == len(message) {
+		target = strings.TrimSpace(message[loc[1]:])
+	}
+	email := pa.intents.ExtractEmail(target)
+	userID := pa.intents.ExtractUserID(target)
+	if email == "" && userID == "" {
+		return pa.teamResponse(context, t(context, "team.remove_target"))
+	}
+
+	team := make([]models.TeamMember, 0, len(context.ProjectData.Team))
+	var removed *models.TeamMember
+	for i, member := range context.ProjectData.Team {
+		if removed == nil && matchesMember(member, userID, email) {
+			removed = &context.ProjectData.Team[i]
+			continue
+		}
+		team = append(team, member)
+	}
+
+	if removed == nil {
+		return pa.teamResponse(context, t(context, "team.member_not_found", target))
+	}
+
+	note := t(context, "team.removed", removed.Name, removed.Lastname)
+	context.ProjectData.Team = team
+	return pa.teamResponse(context, note)
+}
+
+// parseMemberRequests разбирает сообщение на участников: email или ID и роль для каждого
+func (pa *ProjectAssistant) parseMemberRequests(locale i18n.Locale, message string) ([]memberRequest, []string) {
+	var requests []memberRequest
+	var notes []string
+
+	for _, chunk := range teamSeparatorRegex.Split(message, -1) {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+
+		req := memberRequest{raw: chunk}
+		req.email = strings.ToLower(pa.intents.ExtractEmail(chunk))
+		if req.email == "" {
+			req.userID = pa.intents.ExtractUserID(chunk)
+		}
+		if req.email == "" && req.userID == "" {
+			notes = append(notes, i18n.T(locale, "team.not_understood", chunk))
+			continue
+		}
+
+		req.role = pa.intents.DetectRole(locale, chunk)
+		if req.role == "" {
+			req.role = defaultMemberRole
+		}
+
+		requests = append(requests, req)
+	}
+
+	return requests, notes
+}
+
+// TeamEmails возвращает email из сообщения на шаге команды, которые нужно сопоставить
+// с пользователями до обработки сообщения. Для остальных шагов и команд возвращает nil.
+func (pa *ProjectAssistant) TeamEmails(context *models.ProjectCreationContext, message string) []string {
+	if context == nil {
+		return nil
+	}
+	step, ok := pa.flow.Step(context.CurrentStep)
+	if !ok || step.Handler != "team" || removeWordsRegex.MatchString(strings.ToLower(strings.TrimSpace(message))) {
+		return nil
+	}
+
+	requests, _ := pa.parseMemberRequests(localeOf(context), message)
+	var emails []string
+	for _, req := range requests {
+		if req.email != "" && !isTeamMember(context.ProjectData.Team, "", req.email) {
+			emails = append(emails, req.email)
+		}
+	}
+	return emails
+}
+
+// prefetchUsers загружает пользователей, указанных по ID или найденных по email, одним запросом
+// к сервису авторизации
+func (pa *ProjectAssistant) prefetchUsers(requests []memberRequest, known map[string]string) map[string]*auth_v1.User {
+	ids := make([]string, 0, len(requests))
+	for _, req := range requests {
+		if id := requestUserID(req, known); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		return nil
+	}
+
+	users, err := auth.GetUsersByIDs(context.Background(), ids)
+	if err != nil {
+		log.Printf("Ошибка пакетного поиска пользователей: %v", err)
+		return nil
+	}
+
+	byID := make(map[string]*auth_v1.User, len(users))
+	for _, user := range users {
+		byID[user.GetId()] = user
+	}
+	return byID
+}
+
+// resolveUser находит пользователя в сервисе авторизации. Пользователь, указанный по email,
+// ищется по идентификатору, найденному среди участников проектов.
+func (pa *ProjectAssistant) resolveUser(req memberRequest, prefetched map[string]*auth_v1.User, known map[string]string) (*auth_v1.User, error) {
+	userID := requestUserID(req, known)
+	if userID == "" {
+		return nil, errEmailNotKnown
+	}
+
+	if user, ok := prefetched[userID]; ok {
+		return user, nil
+	}
+
+	user, err := auth.GetUserByID(context.Background(), userID)
+	if err != nil {
+		log.Printf("Ошибка поиска пользователя %s: %v", userID, err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// requestUserID возвращает идентификатор пользователя из запроса: указанный явно или найденный по email
+func requestUserID(req memberRequest, known map[string]string) string {
+	if req.email != "" {
+		return known[req.email]
+	}
+	return req.userID
+}
+
+// teamResponse формирует ответ шага команды с текущим составом
+func (pa *ProjectAssistant) teamResponse(context *models.ProjectCreationContext, notes string) *models.AssistantResponse {
+	var message strings.Builder
+	if notes != "" {
+		message.WriteString(notes)
+		message.WriteString("\n\n")
+	}
+	message.WriteString(t(context, "team.current"))
+	message.WriteString(prompts.GetTeamSummary(localeOf(context), context.ProjectData.Team))
+	message.WriteString("\n\n")
+	message.WriteString(t(context, "team.next_hint"))
+
+	return &models.AssistantResponse{
+		Message:        message.String(),
+		ProjectContext: *context,
+	}
+}
+
+func describeLookupError(locale i18n.Locale, err error) string {
+	switch {
+	case errors.Is(err, errEmailNotKnown):
+		return i18n.T(locale, "team.email_not_found")
+	case errors.Is(err, auth.ErrUserNotFound):
+		return i18n.T(locale, "team.user_not_found")
+	default:
+		return i18n.T(locale, "team.lookup_failed")
+	}
+}
+
+func isTeamMember(team []models.TeamMember, userID, email string) bool {
+	for _, member := range team {
+		if matchesMember(member, userID, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesMember(member models.TeamMember, userID, email string) bool {
+	if userID != "" && member.ID == userID {
+		return true
+	}
+	return email != "" && strings.EqualFold(member.Email, email)
+}
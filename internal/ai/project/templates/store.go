@@ -0,0 +1,208 @@
+package templates
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed builtin_templates.json
+var builtinTemplates []byte
+
+var (
+	ErrTemplateNotFound = errors.New("project template not found")
+	// ErrTemplateForbidden возвращается при попытке изменить встроенный или чужой шаблон
+	ErrTemplateForbidden = errors.New("project template cannot be modified by this user")
+)
+
+// Owner определяет, чьи шаблоны видит пользователь. Шаблонов организаций нет, пока сервис
+// авторизации не отдает проверенный идентификатор организации: поле company в профиле
+// пользователь меняет сам.
+type Owner struct {
+	UserID string
+}
+
+// Store хранит личные шаблоны пользователей в базе данных и отдает встроенные шаблоны
+type Store struct {
+	db       *sqlx.DB
+	builtins []models.ProjectTemplate
+}
+
+func NewStore(db *sqlx.DB) (*Store, error) {
+	var builtins []models.ProjectTemplate
+	if err := json.Unmarshal(builtinTemplates, &builtins); err != nil {
+		return nil, fmt.Errorf("ошибка разбора встроенных шаблонов: %w", err)
+	}
+	return &Store{db: db, builtins: builtins}, nil
+}
+
+const templateColumns = `id::text AS id, scope, owner_id, created_by, name, description, priority,
+        deadline_offset_days, confidentiality, team_roles, created_at, updated_at`
+
+// List возвращает встроенные шаблоны и личные шаблоны пользователя
+func (s *Store) List(ctx context.Context, owner Owner) ([]models.ProjectTemplate, error) {
+	query := `
+        SELECT ` + templateColumns + `
+        FROM project_templates
+        WHERE scope = 'user' AND owner_id = $1
+        ORDER BY name`
+
+	var stored []models.ProjectTemplate
+	if err := s.db.SelectContext(ctx, &stored, query, owner.UserID); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.ProjectTemplate, 0, len(s.builtins)+len(stored))
+	result = append(result, s.builtins...)
+	return append(result, stored...), nil
+}
+
+// Get возвращает шаблон, доступный пользователю
+func (s *Store) Get(ctx context.Context, owner Owner, id string) (*models.ProjectTemplate, error) {
+	for i := range s.builtins {
+		if s.builtins[i].ID == id {
+			template := s.builtins[i]
+			return &template, nil
+		}
+	}
+
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	query := `SELECT ` + templateColumns + ` FROM project_templates WHERE id = $1`
+	var template models.ProjectTemplate
+	if err := s.db.GetContext(ctx, &template, query, numericID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	if !visible(&template, owner) {
+		return nil, ErrTemplateNotFound
+	}
+
+	return &template, nil
+}
+
+// FindByName ищет доступный пользователю шаблон по названию или идентификатору без учета регистра.
+// Точное совпадение важнее совпадения по началу названия.
+func (s *Store) FindByName(ctx context.Context, owner Owner, name string) (*models.ProjectTemplate, error) {
+	all, err := s.List(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := normalizeName(name)
+	if wanted == "" {
+		return nil, ErrTemplateNotFound
+	}
+
+	var prefixMatch *models.ProjectTemplate
+	for i := range all {
+		template := &all[i]
+		if normalizeName(template.Name) == wanted || normalizeName(template.ID) == wanted {
+			return template, nil
+		}
+		if prefixMatch == nil && strings.HasPrefix(normalizeName(template.Name), wanted) {
+			prefixMatch = template
+		}
+	}
+	if prefixMatch != nil {
+		return prefixMatch, nil
+	}
+
+	return nil, ErrTemplateNotFound
+}
+
+// Create сохраняет новый личный шаблон пользователя
+func (s *Store) Create(ctx context.Context, owner Owner, template *models.ProjectTemplate) error {
+	template.Scope = models.TemplateScopeUser
+	template.OwnerID = owner.UserID
+	template.CreatedBy = owner.UserID
+
+	query := `
+        INSERT INTO project_templates (scope, owner_id, created_by, name, description, priority,
+            deadline_offset_days, confidentiality, team_roles)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id::text, created_at, updated_at`
+
+	return s.db.QueryRowxContext(ctx, query,
+		template.Scope, template.OwnerID, template.CreatedBy, template.Name, template.Description,
+		template.Priority, template.DeadlineOffsetDays, template.Confidentiality, template.TeamRoles,
+	).Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt)
+}
+
+// Update изменяет шаблон. Изменять шаблон может только его автор.
+func (s *Store) Update(ctx context.Context, owner Owner, id string, template *models.ProjectTemplate) error {
+	existing, err := s.editable(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+
+	template.ID = existing.ID
+	template.Scope = models.TemplateScopeUser
+	template.OwnerID = owner.UserID
+	template.CreatedBy = existing.CreatedBy
+	template.CreatedAt = existing.CreatedAt
+
+	query := `
+        UPDATE project_templates
+        SET scope = $2, owner_id = $3, name = $4, description = $5, priority = $6,
+            deadline_offset_days = $7, confidentiality = $8, team_roles = $9, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+        RETURNING updated_at`
+
+	return s.db.QueryRowxContext(ctx, query,
+		existing.ID, template.Scope, template.OwnerID, template.Name, template.Description,
+		template.Priority, template.DeadlineOffsetDays, template.Confidentiality, template.TeamRoles,
+	).Scan(&template.UpdatedAt)
+}
+
+// Delete удаляет шаблон. Удалять шаблон может только его автор.
+func (s *Store) Delete(ctx context.Context, owner Owner, id string) error {
+	existing, err := s.editable(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `DELETE FROM project_templates WHERE id = $1`, existing.ID)
+	return err
+}
+
+// editable возвращает шаблон, если пользователь может его изменять
+func (s *Store) editable(ctx context.Context, owner Owner, id string) (*models.ProjectTemplate, error) {
+	existing, err := s.Get(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Scope == models.TemplateScopeSystem || existing.CreatedBy != owner.UserID {
+		return nil, ErrTemplateForbidden
+	}
+	return existing, nil
+}
+
+func visible(template *models.ProjectTemplate, owner Owner) bool {
+	switch template.Scope {
+	case models.TemplateScopeUser:
+		return template.OwnerID == owner.UserID
+	default:
+		return false
+	}
+}
+
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Trim(name, `"'«»`)
+	name = strings.ReplaceAll(name, "-", " ")
+	return strings.Join(strings.Fields(name), " ")
+}
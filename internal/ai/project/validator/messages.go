@@ -0,0 +1,163 @@
+package validator
+
+import "github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+
+func init() {
+	i18n.Register(i18n.RU, map[string]string{
+		"validation.name_too_short":               "название должно содержать минимум %d символа",
+		"validation.name_too_long":                "название не может быть длиннее %d символов",
+		"validation.name_invalid_chars":           "название может содержать только буквы, цифры, пробелы и символы %s",
+		"validation.description_too_short":        "описание должно содержать минимум %d символов",
+		"validation.description_too_long":         "описание не может быть длиннее %d символов",
+		"validation.deadline_required":            "дедлайн обязателен",
+		"validation.deadline_invalid_format":      "неверный формат даты, используйте ДД.ММ.ГГГГ",
+		"validation.deadline_in_past":             "дата не может быть в прошлом",
+		"validation.priority_invalid":             "некорректный приоритет, допустимые значения: ВЫСОКИЙ, СРЕДНИЙ, НИЗКИЙ",
+		"validation.budget_invalid_format":        "бюджет указан в неверном формате, ожидается сумма и валюта, например: 150000 RUB",
+		"validation.spent_invalid_format":         "сумма расходов указана в неверном формате, ожидается сумма и валюта, например: 150000 RUB",
+		"validation.status_invalid":               "некорректный статус, допустимые значения: Запланирован, В процессе, Приостановлен, Завершен",
+		"validation.confidentiality_invalid":      "некорректный уровень конфиденциальности, допустимые значения: Только для участников, Вся организация, Публичный",
+		"validation.member_email_invalid":         "некорректный email: %s",
+		"validation.member_role_invalid":          "некорректная роль для %s, допустимые значения: менеджер, редактор, читатель",
+		"validation.member_name_required":         "имя участника обязательно",
+		"validation.member_lastname_required":     "фамилия участника обязательна",
+		"validation.unknown_step":                 "неизвестный шаг создания проекта: %s",
+		"validation.unrecognized_amount":          "не удалось распознать сумму",
+		"validation.amount_range":                 "укажите одну сумму, а не диапазон",
+		"validation.unrecognized_status":          "не удалось распознать статус",
+		"validation.unrecognized_confidentiality": "не удалось распознать уровень конфиденциальности",
+		"validation.template_name_required":       "укажите название шаблона",
+		"validation.template_name_too_long":       "название шаблона не может быть длиннее %d символов",
+		"validation.template_scope_invalid":       "некорректная область видимости шаблона, допустимое значение: user",
+		"validation.template_offset_invalid":      "срок проекта в шаблоне должен быть от 0 до %d дней",
+		"validation.template_role_invalid":        "некорректная роль в шаблоне: %s, допустимые значения: MANAGER, EDITOR, READER",
+		"validation.template_role_count_invalid":  "количество участников с ролью %s не может быть отрицательным",
+		"validation.plan_empty":                   "в плане нет ни одного этапа",
+		"validation.plan_unparsable":              "не удалось разобрать план",
+		"validation.plan_title_required":          "у каждого этапа и задачи плана должно быть название",
+		"validation.plan_estimate_invalid":        "у задачи «%s» должна быть положительная оценка трудоемкости",
+		"validation.plan_date_invalid":            "некорректная дата в плане: %s, используйте ДД.ММ.ГГГГ",
+		"validation.plan_date_in_past":            "дата %s в плане уже прошла",
+		"validation.plan_dates_order":             "дата окончания %s в плане раньше даты начала %s",
+		"validation.plan_after_deadline":          "дата %s в плане позже дедлайна проекта",
+		"validation.plan_milestones_order":        "этап «%s» заканчивается раньше предыдущего этапа",
+		"validation.plan_task_outside_milestone":  "сроки задачи «%s» выходят за рамки этапа «%s»",
+		"validation.risk_empty":                   "реестр рисков пуст",
+		"validation.risk_unparsable":              "не удалось разобрать реестр рисков",
+		"validation.risk_title_required":          "у каждого риска должно быть описание",
+		"validation.risk_category_invalid":        "некорректная категория риска: %s",
+		"validation.risk_level_invalid":           "некорректный уровень риска: %s, допустимые значения: low, medium, high",
+		"validation.risk_mitigation_required":     "для риска «%s» не указаны меры снижения",
+
+		"warning.deadline_soon":            "до дедлайна осталось меньше %d рабочих дней, проверьте, что сроки реалистичны.",
+		"warning.deadline_non_working_day": "дедлайн %s приходится на нерабочий день.",
+		"warning.deadline_infeasible":      "оценка трудоемкости %s чел.-дн. больше, чем команда успеет за %d рабочих дней до дедлайна.",
+		"warning.description_no_goals":     "в описании не указаны цели или ожидаемые результаты проекта.",
+		"warning.high_priority_no_team":    "у проекта высокий приоритет, но в команде пока никого нет.",
+		"warning.spent_over_budget":        "потрачено (%s) больше, чем выделено бюджета (%s).",
+		"warning.name_duplicate":           "проект с таким названием уже есть: %s.",
+		"warning.name_similar":             "есть проекты с похожим названием: %s.",
+
+		"enum.priority.ВЫСОКИЙ":                      "Высокий",
+		"enum.priority.СРЕДНИЙ":                      "Средний",
+		"enum.priority.НИЗКИЙ":                       "Низкий",
+		"enum.status.ЗАПЛАНИРОВАН":                   "Запланирован",
+		"enum.status.В_ПРОЦЕССЕ":                     "В процессе",
+		"enum.status.ПРИОСТАНОВЛЕН":                  "Приостановлен",
+		"enum.status.ЗАВЕРШЕН":                       "Завершен",
+		"enum.confidentiality.Только для участников": "Только для участников",
+		"enum.confidentiality.Вся организация":       "Вся организация",
+		"enum.confidentiality.Публичный":             "Публичный",
+		"enum.role.MANAGER":                          "Менеджер",
+		"enum.role.EDITOR":                           "Редактор",
+		"enum.role.READER":                           "Читатель",
+		"enum.risk_category.schedule":                "Сроки",
+		"enum.risk_category.budget":                  "Бюджет",
+		"enum.risk_category.resources":               "Ресурсы",
+		"enum.risk_category.scope":                   "Объем работ",
+		"enum.risk_category.technical":               "Технический",
+		"enum.risk_category.external":                "Внешний",
+		"enum.risk_level.low":                        "низкая",
+		"enum.risk_level.medium":                     "средняя",
+		"enum.risk_level.high":                       "высокая",
+	})
+
+	i18n.Register(i18n.EN, map[string]string{
+		"validation.name_too_short":               "the name must be at least %d characters long",
+		"validation.name_too_long":                "the name cannot be longer than %d characters",
+		"validation.name_invalid_chars":           "the name may only contain letters, digits, spaces and the characters %s",
+		"validation.description_too_short":        "the description must be at least %d characters long",
+		"validation.description_too_long":         "the description cannot be longer than %d characters",
+		"validation.deadline_required":            "the deadline is required",
+		"validation.deadline_invalid_format":      "invalid date format, use DD.MM.YYYY",
+		"validation.deadline_in_past":             "the date cannot be in the past",
+		"validation.priority_invalid":             "invalid priority, allowed values: high, medium, low",
+		"validation.budget_invalid_format":        "invalid budget format, expected an amount and a currency, e.g. 150000 USD",
+		"validation.spent_invalid_format":         "invalid spent amount format, expected an amount and a currency, e.g. 150000 USD",
+		"validation.status_invalid":               "invalid status, allowed values: planned, in progress, on hold, completed",
+		"validation.confidentiality_invalid":      "invalid confidentiality level, allowed values: members only, whole organization, public",
+		"validation.member_email_invalid":         "invalid email: %s",
+		"validation.member_role_invalid":          "invalid role for %s, allowed values: manager, editor, viewer",
+		"validation.member_name_required":         "the member's first name is required",
+		"validation.member_lastname_required":     "the member's last name is required",
+		"validation.unknown_step":                 "unknown project creation step: %s",
+		"validation.unrecognized_amount":          "the amount was not recognized",
+		"validation.amount_range":                 "please enter a single amount, not a range",
+		"validation.unrecognized_status":          "the status was not recognized",
+		"validation.unrecognized_confidentiality": "the confidentiality level was not recognized",
+		"validation.template_name_required":       "the template name is required",
+		"validation.template_name_too_long":       "the template name cannot be longer than %d characters",
+		"validation.template_scope_invalid":       "invalid template scope, allowed value: user",
+		"validation.template_offset_invalid":      "the template project duration must be between 0 and %d days",
+		"validation.template_role_invalid":        "invalid template role: %s, allowed values: MANAGER, EDITOR, READER",
+		"validation.template_role_count_invalid":  "the number of members with role %s cannot be negative",
+		"validation.plan_empty":                   "the plan has no milestones",
+		"validation.plan_unparsable":              "the plan could not be parsed",
+		"validation.plan_title_required":          "every milestone and task in the plan needs a title",
+		"validation.plan_estimate_invalid":        "the task \"%s\" needs a positive effort estimate",
+		"validation.plan_date_invalid":            "invalid date in the plan: %s, use DD.MM.YYYY",
+		"validation.plan_date_in_past":            "the plan date %s is in the past",
+		"validation.plan_dates_order":             "the plan end date %s is before the start date %s",
+		"validation.plan_after_deadline":          "the plan date %s is after the project deadline",
+		"validation.plan_milestones_order":        "the milestone \"%s\" ends before the previous milestone",
+		"validation.plan_task_outside_milestone":  "the dates of the task \"%s\" fall outside the milestone \"%s\"",
+		"validation.risk_empty":                   "the risk register is empty",
+		"validation.risk_unparsable":              "the risk register could not be parsed",
+		"validation.risk_title_required":          "every risk needs a description",
+		"validation.risk_category_invalid":        "invalid risk category: %s",
+		"validation.risk_level_invalid":           "invalid risk level: %s, allowed values: low, medium, high",
+		"validation.risk_mitigation_required":     "no mitigation is given for the risk \"%s\"",
+
+		"warning.deadline_soon":            "the deadline is less than %d working days away, make sure the timeline is realistic.",
+		"warning.deadline_non_working_day": "the deadline %s falls on a non-working day.",
+		"warning.deadline_infeasible":      "the effort estimate of %s person-days exceeds what the team can do in the %d working days before the deadline.",
+		"warning.description_no_goals":     "the description does not mention the project's goals or expected results.",
+		"warning.high_priority_no_team":    "the project has high priority but nobody is on the team yet.",
+		"warning.spent_over_budget":        "the amount spent (%s) exceeds the budget (%s).",
+		"warning.name_duplicate":           "a project with this name already exists: %s.",
+		"warning.name_similar":             "there are projects with a similar name: %s.",
+
+		"enum.priority.ВЫСОКИЙ":                      "High",
+		"enum.priority.СРЕДНИЙ":                      "Medium",
+		"enum.priority.НИЗКИЙ":                       "Low",
+		"enum.status.ЗАПЛАНИРОВАН":                   "Planned",
+		"enum.status.В_ПРОЦЕССЕ":                     "In progress",
+		"enum.status.ПРИОСТАНОВЛЕН":                  "On hold",
+		"enum.status.ЗАВЕРШЕН":                       "Completed",
+		"enum.confidentiality.Только для участников": "Members only",
+		"enum.confidentiality.Вся организация":       "Whole organization",
+		"enum.confidentiality.Публичный":             "Public",
+		"enum.role.MANAGER":                          "Manager",
+		"enum.role.EDITOR":                           "Editor",
+		"enum.role.READER":                           "Viewer",
+		"enum.risk_category.schedule":                "Schedule",
+		"enum.risk_category.budget":                  "Budget",
+		"enum.risk_category.resources":               "Resources",
+		"enum.risk_category.scope":                   "Scope",
+		"enum.risk_category.technical":               "Technical",
+		"enum.risk_category.external":                "External",
+		"enum.risk_level.low":                        "low",
+		"enum.risk_level.medium":                     "medium",
+		"enum.risk_level.high":                       "high",
+	})
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+const planDateLayout = "02.01.2006"
+
+// ValidatePlan проверяет план проекта: даты этапов и задач идут по порядку, не раньше
+// сегодняшнего дня и не позже дедлайна, у задач есть положительная оценка.
+// Поле ошибки содержит путь внутри плана, например "milestones[0].tasks[1].due_date".
+func ValidatePlan(plan *models.ProjectPlan, deadline string, now time.Time) error {
+	if plan == nil || len(plan.Milestones) == 0 {
+		return NewError("plan_empty")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	// Если дедлайн не указан или некорректен, об этом сообщит правило дедлайна
+	end, err := time.Parse(planDateLayout, deadline)
+	hasDeadline := err == nil
+
+	var previousDue time.Time
+	for i, milestone := range plan.Milestones {
+		path := fmt.Sprintf("milestones[%d]", i)
+		if strings.TrimSpace(milestone.Title) == "" {
+			return fieldError(path+".title", "plan_title_required")
+		}
+
+		start, due, err := planPeriod(path, milestone.StartDate, milestone.DueDate, today, end, hasDeadline)
+		if err != nil {
+			return err
+		}
+		if due.Before(previousDue) {
+			return fieldError(path+".due_date", "plan_milestones_order", milestone.Title)
+		}
+		previousDue = due
+
+		for j, task := range milestone.Tasks {
+			taskPath := fmt.Sprintf("%s.tasks[%d]", path, j)
+			if strings.TrimSpace(task.Title) == "" {
+				return fieldError(taskPath+".title", "plan_title_required")
+			}
+			if task.EstimateDays <= 0 {
+				return fieldError(taskPath+".estimate_days", "plan_estimate_invalid", task.Title)
+			}
+
+			taskStart, taskDue, err := planPeriod(taskPath, task.StartDate, task.DueDate, today, end, hasDeadline)
+			if err != nil {
+				return err
+			}
+			if taskStart.Before(start) || taskDue.After(due) {
+				return fieldError(taskPath+".due_date", "plan_task_outside_milestone", task.Title, milestone.Title)
+			}
+		}
+	}
+
+	return nil
+}
+
+// planPeriod разбирает и проверяет даты начала и окончания этапа или задачи
+func planPeriod(path, startDate, dueDate string, today, deadline time.Time, hasDeadline bool) (time.Time, time.Time, error) {
+	start, err := time.Parse(planDateLayout, startDate)
+	if err != nil {
+		return start, start, fieldError(path+".start_date", "plan_date_invalid", startDate)
+	}
+	due, err := time.Parse(planDateLayout, dueDate)
+	if err != nil {
+		return start, due, fieldError(path+".due_date", "plan_date_invalid", dueDate)
+	}
+
+	switch {
+	case start.Before(today):
+		return start, due, fieldError(path+".start_date", "plan_date_in_past", startDate)
+	case due.Before(start):
+		return start, due, fieldError(path+".due_date", "plan_dates_order", dueDate, startDate)
+	case hasDeadline && due.After(deadline):
+		return start, due, fieldError(path+".due_date", "plan_after_deadline", dueDate)
+	}
+	return start, due, nil
+}
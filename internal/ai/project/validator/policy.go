@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Имена политик символов для названия и описания проекта
+const (
+	// PolicyUnicode считает длину в графемах и допускает буквы любых алфавитов и распространенные знаки
+	PolicyUnicode = "unicode"
+	// PolicyCompat повторяет прежние правила проверки: длина в байтах UTF-8, только кириллица
+	// без "ё", латиница, цифры, пробелы, тире и подчеркивания. Нужна, пока сервис проектов
+	// проверяет данные по этим же правилам.
+	PolicyCompat = "compat"
+)
+
+// CharacterPolicy описывает, как считается длина текста и какие символы допустимы в названии
+type CharacterPolicy struct {
+	Name string
+	// NamePattern - допустимые символы названия
+	NamePattern *regexp.Regexp
+	// NameSymbols - перечень допустимых знаков для сообщения об ошибке
+	NameSymbols string
+	// Length считает длину названия и описания
+	Length func(text string) int
+}
+
+var (
+	policyMu sync.RWMutex
+	policies = map[string]*CharacterPolicy{
+		PolicyUnicode: {
+			Name:        PolicyUnicode,
+			NamePattern: regexp.MustCompile(`^[\p{L}\p{M}\p{N}\s\-_.,&()'’"«»:!?/+#№]+$`),
+			NameSymbols: `- _ . , & ( ) ' " « » : ! ? / + # №`,
+			Length:      GraphemeCount,
+		},
+		PolicyCompat: {
+			Name:        PolicyCompat,
+			NamePattern: regexp.MustCompile(`^[а-яА-Яa-zA-Z0-9\s\-_]+$`),
+			NameSymbols: "- _",
+			Length:      func(text string) int { return len(text) },
+		},
+	}
+	currentPolicy = policies[PolicyUnicode]
+)
+
+// SetCharacterPolicy выбирает политику символов по имени; пустое имя означает PolicyUnicode
+func SetCharacterPolicy(name string) error {
+	if name == "" {
+		name = PolicyUnicode
+	}
+	policy, ok := policies[name]
+	if !ok {
+		return fmt.Errorf("неизвестная политика символов: %s", name)
+	}
+
+	policyMu.Lock()
+	currentPolicy = policy
+	policyMu.Unlock()
+	return nil
+}
+
+// Policy возвращает текущую политику символов
+func Policy() *CharacterPolicy {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+	return currentPolicy
+}
+
+// TextLength возвращает длину текста по правилам текущей политики
+func TextLength(text string) int {
+	return Policy().Length(text)
+}
+
+// GraphemeCount приближенно считает видимые символы (графемы): комбинируемые знаки,
+// модификаторы эмодзи, селекторы вариантов и последовательности с ZWJ присоединяются
+// к предыдущему символу, пара региональных индикаторов (флаг) считается одним символом.
+func GraphemeCount(text string) int {
+	count := 0
+	joinNext := false
+	regionalPending := false
+	var previous rune
+
+	for _, r := range text {
+		switch {
+		case joinNext:
+			joinNext = false
+		case r == '\u200d':
+			joinNext = true
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc),
+			r >= 0xFE00 && r <= 0xFE0F,
+			r >= 0xE0100 && r <= 0xE01EF,
+			r >= 0x1F3FB && r <= 0x1F3FF:
+		case r == '\n' && previous == '\r':
+		case r >= 0x1F1E6 && r <= 0x1F1FF:
+			if regionalPending {
+				regionalPending = false
+			} else {
+				regionalPending = true
+				count++
+			}
+		default:
+			count++
+		}
+
+		if !(r >= 0x1F1E6 && r <= 0x1F1FF) {
+			regionalPending = false
+		}
+		previous = r
+	}
+
+	// Строка из одних комбинируемых знаков все равно не пустая
+	if count == 0 && utf8.RuneCountInString(text) > 0 {
+		return 1
+	}
+	return count
+}
+
+// hasLetterOrDigit сообщает, есть ли в тексте хотя бы одна буква или цифра
+func hasLetterOrDigit(text string) bool {
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+// usePolicy включает политику символов на время теста
+func usePolicy(t *testing.T, name string) {
+	t.Helper()
+
+	previous := Policy().Name
+	if err := SetCharacterPolicy(name); err != nil {
+		t.Fatalf("SetCharacterPolicy(%q): %v", name, err)
+	}
+	t.Cleanup(func() { SetCharacterPolicy(previous) })
+}
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"пустая строка", "", 0},
+		{"латиница", "abc", 3},
+		{"кириллица", "Привет", 6},
+		{"ё", "ёлка", 4},
+		{"комбинируемый знак", "e\u0301", 1},
+		{"только комбинируемый знак", "\u0301", 1},
+		{"несколько комбинируемых знаков", "a\u0323\u0301b", 2},
+		{"селектор варианта", "\u2764\ufe0f", 1},
+		{"модификатор тона", "👍🏽", 1},
+		{"последовательность с ZWJ", "\U0001F468\u200d\U0001F469\u200d\U0001F467", 1},
+		{"ZWJ с модификатором", "\U0001F469\U0001F3FD\u200d\U0001F4BB", 1},
+		{"флаг", "🇷🇺", 1},
+		{"два флага подряд", "🇷🇺🇺🇸", 2},
+		{"флаг и непарный индикатор", "🇷🇺🇺", 2},
+		{"CRLF", "a\r\nb", 3},
+		{"CR и LF отдельно", "a\n\rb", 4},
+		{"эмодзи в тексте", "Проект 🚀", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GraphemeCount(tt.text); got != tt.want {
+				t.Errorf("GraphemeCount(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNameUnicodePolicy(t *testing.T) {
+	usePolicy(t, PolicyUnicode)
+
+	tests := []struct {
+		name     string
+		input    string
+		wantCode string
+	}{
+		{"60 букв кириллицей", strings.Repeat("Я", 60), ""},
+		{"100 букв кириллицей", strings.Repeat("Я", MaxNameLength), ""},
+		{"101 буква кириллицей", strings.Repeat("Я", MaxNameLength+1), "name_too_long"},
+		{"слишком короткое", "Ай", "name_too_short"},
+		{"короткое с пробелами по краям", "  Ай  ", "name_too_short"},
+		{"ё", "Ёлка и ёж", ""},
+		{"точка и запятая", "Проект v2.0, этап 1", ""},
+		{"амперсанд", "Рога & копыта", ""},
+		{"скобки", "CRM (пилот)", ""},
+		{"кавычки-ёлочки", "Проект «Север»", ""},
+		{"другие алфавиты", "Café 東京", ""},
+		{"комбинируемые знаки", "Cafe\u0301 проект", ""},
+		{"угловые скобки", "<script>", "name_invalid_chars"},
+		{"эмодзи", "Проект 🚀", "name_invalid_chars"},
+		{"только знаки", "---", "name_invalid_chars"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(validateName(tt.input)); got != tt.wantCode {
+				t.Errorf("validateName(%q) code = %q, want %q", tt.input, got, tt.wantCode)
+			}
+		})
+	}
+}
+
+// Режим совместимости повторяет правила сервиса проектов: длина в байтах UTF-8,
+// кириллица без "ё", латиница, цифры, пробелы, тире и подчеркивания
+func TestValidateNameCompatPolicy(t *testing.T) {
+	usePolicy(t, PolicyCompat)
+
+	tests := []struct {
+		name     string
+		input    string
+		wantCode string
+	}{
+		{"50 букв кириллицей - 100 байт", strings.Repeat("Я", 50), ""},
+		{"51 буква кириллицей - 102 байта", strings.Repeat("Я", 51), "name_too_long"},
+		{"60 букв кириллицей - 120 байт", strings.Repeat("Я", 60), "name_too_long"},
+		{"100 букв латиницей", strings.Repeat("a", MaxNameLength), ""},
+		{"101 буква латиницей", strings.Repeat("a", MaxNameLength+1), "name_too_long"},
+		{"короткое в символах, но не в байтах", "Ай", ""},
+		{"слишком короткое", "ab", "name_too_short"},
+		{"тире и подчеркивание", "Проект_1 - тест", ""},
+		{"ё", "Ёлка", "name_invalid_chars"},
+		{"точка", "Проект v2.0", "name_invalid_chars"},
+		{"запятая", "Этап 1, этап 2", "name_invalid_chars"},
+		{"амперсанд", "Рога & копыта", "name_invalid_chars"},
+		{"скобки", "CRM (пилот)", "name_invalid_chars"},
+		{"другие алфавиты", "Café", "name_invalid_chars"},
+		{"только знаки", "___", "name_invalid_chars"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(validateName(tt.input)); got != tt.wantCode {
+				t.Errorf("validateName(%q) code = %q, want %q", tt.input, got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestValidateDescriptionPolicies(t *testing.T) {
+	tests := []struct {
+		policy   string
+		input    string
+		wantCode string
+	}{
+		{PolicyUnicode, strings.Repeat("я", MaxDescriptionLength), ""},
+		{PolicyUnicode, strings.Repeat("я", MaxDescriptionLength+1), "description_too_long"},
+		{PolicyUnicode, strings.Repeat("👍🏽", MaxDescriptionLength), ""},
+		{PolicyUnicode, "Коротко 🚀", "description_too_short"},
+		{PolicyCompat, strings.Repeat("я", MaxDescriptionLength/2), ""},
+		{PolicyCompat, strings.Repeat("я", MaxDescriptionLength/2+1), "description_too_long"},
+		{PolicyCompat, "Коротко", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			usePolicy(t, tt.policy)
+			if got := Code(validateDescription(tt.input)); got != tt.wantCode {
+				t.Errorf("validateDescription(%d символов) code = %q, want %q", len([]rune(tt.input)), got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSetCharacterPolicy(t *testing.T) {
+	usePolicy(t, PolicyCompat)
+
+	if err := SetCharacterPolicy(""); err != nil {
+		t.Fatalf("SetCharacterPolicy(\"\"): %v", err)
+	}
+	if got := Policy().Name; got != PolicyUnicode {
+		t.Errorf("policy after empty name = %q, want %q", got, PolicyUnicode)
+	}
+
+	if err := SetCharacterPolicy("ascii"); err == nil {
+		t.Error("SetCharacterPolicy(\"ascii\") succeeded, want error")
+	}
+	if got := Policy().Name; got != PolicyUnicode {
+		t.Errorf("policy after unknown name = %q, want %q", got, PolicyUnicode)
+	}
+}
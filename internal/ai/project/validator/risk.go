@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+// RiskCategories содержит допустимые категории рисков
+var RiskCategories = []string{"schedule", "budget", "resources", "scope", "technical", "external"}
+
+// RiskLevels содержит допустимые уровни вероятности и влияния риска
+var RiskLevels = []string{"low", "medium", "high"}
+
+// ValidateRisks проверяет реестр рисков. Поле ошибки содержит путь вида "risks[0].impact".
+func ValidateRisks(risks []models.Risk) error {
+	if len(risks) == 0 {
+		return NewError("risk_empty")
+	}
+
+	for i, risk := range risks {
+		path := fmt.Sprintf("risks[%d]", i)
+		switch {
+		case strings.TrimSpace(risk.Title) == "":
+			return fieldError(path+".title", "risk_title_required")
+		case !contains(RiskCategories, risk.Category):
+			return fieldError(path+".category", "risk_category_invalid", risk.Category)
+		case !contains(RiskLevels, risk.Likelihood):
+			return fieldError(path+".likelihood", "risk_level_invalid", risk.Likelihood)
+		case !contains(RiskLevels, risk.Impact):
+			return fieldError(path+".impact", "risk_level_invalid", risk.Impact)
+		case strings.TrimSpace(risk.Mitigation) == "":
+			return fieldError(path+".mitigation", "risk_mitigation_required", risk.Title)
+		}
+	}
+	return nil
+}
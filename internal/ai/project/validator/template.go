@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+// MaxDeadlineOffsetDays ограничивает типичный срок проекта в шаблоне десятью годами
+const MaxDeadlineOffsetDays = 3650
+
+// ValidateTemplate проверяет шаблон проекта перед сохранением
+func ValidateTemplate(template *models.ProjectTemplate) error {
+	name := strings.TrimSpace(template.Name)
+	if name == "" {
+		return NewError("template_name_required")
+	}
+	if TextLength(name) > MaxNameLength {
+		return NewError("template_name_too_long", MaxNameLength)
+	}
+
+	if template.Scope != models.TemplateScopeUser {
+		return NewError("template_scope_invalid")
+	}
+
+	if TextLength(strings.TrimSpace(template.Description)) > MaxDescriptionLength {
+		return NewError("description_too_long", MaxDescriptionLength)
+	}
+	if template.Priority != "" && !contains(Priorities, template.Priority) {
+		return NewError("priority_invalid")
+	}
+	if template.Confidentiality != "" && !contains(ConfidentialityLevels, template.Confidentiality) {
+		return NewError("confidentiality_invalid")
+	}
+	if template.DeadlineOffsetDays < 0 || template.DeadlineOffsetDays > MaxDeadlineOffsetDays {
+		return NewError("template_offset_invalid", MaxDeadlineOffsetDays)
+	}
+
+	for _, role := range template.TeamRoles {
+		if !contains(Roles, role.Role) {
+			return NewError("template_role_invalid", role.Role)
+		}
+		if role.Count < 0 {
+			return NewError("template_role_count_invalid", role.Role)
+		}
+	}
+
+	return nil
+}
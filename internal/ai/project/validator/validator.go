@@ -1,19 +1,33 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
 	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
 )
 
 var (
-	nameRegex  = regexp.MustCompile(`^[а-яА-Яa-zA-Z0-9\s\-_]+$`)
-	emailRegex = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	emailRegex  = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	amountRegex = regexp.MustCompile(`^(\d+(?:\.\d{1,2})?)(?: (RUB|USD|EUR))?$`)
 )
 
+// Priorities содержит канонические коды приоритетов
+var Priorities = []string{"ВЫСОКИЙ", "СРЕДНИЙ", "НИЗКИЙ"}
+
+// ProjectStatuses содержит допустимые статусы проекта
+var ProjectStatuses = []string{"ЗАПЛАНИРОВАН", "В_ПРОЦЕССЕ", "ПРИОСТАНОВЛЕН", "ЗАВЕРШЕН"}
+
+// ConfidentialityLevels содержит допустимые уровни конфиденциальности проекта
+var ConfidentialityLevels = []string{"Только для участников", "Вся организация", "Публичный"}
+
+// Roles содержит канонические коды ролей участников
+var Roles = []string{"MANAGER", "EDITOR", "READER"}
+
 const (
 	MinNameLength        = 3
 	MaxNameLength        = 100
@@ -21,150 +35,236 @@ const (
 	MaxDescriptionLength = 3000
 )
 
-// ValidateProjectData проверяет все данные проекта
-func ValidateProjectData(data *models.ProjectData) models.ValidationState {
-	state := models.ValidationState{
-		Errors:   make(map[string]string),
-		Warnings: make(map[string]string),
-	}
+// Error - ошибка валидации с машиночитаемым кодом. Текст сообщения берется из каталога
+// на языке пользователя.
+type Error struct {
+	Code string
+	Args []interface{}
+	// Field - вложенное поле, к которому относится ошибка, например "email" у участника команды
+	Field string
+}
+
+// NewError создает ошибку валидации с кодом из каталога сообщений
+func NewError(code string, args ...interface{}) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+func (e *Error) Error() string {
+	return e.Localize(i18n.DefaultLocale)
+}
+
+func fieldError(field, code string, args ...interface{}) *Error {
+	err := NewError(code, args...)
+	err.Field = field
+	return err
+}
 
-	// Валидация названия
-	if err := validateName(data.Name); err != nil {
-		state.Errors["name"] = err.Error()
+// Localize возвращает текст ошибки на указанном языке
+func (e *Error) Localize(locale i18n.Locale) string {
+	return i18n.T(locale, "validation."+e.Code, e.Args...)
+}
+
+// Code возвращает машиночитаемый код ошибки валидации или пустую строку
+func Code(err error) string {
+	var validationErr *Error
+	if errors.As(err, &validationErr) {
+		return validationErr.Code
 	}
+	return ""
+}
 
-	// Валидация описания
-	if err := validateDescription(data.Description); err != nil {
-		state.Errors["description"] = err.Error()
+// Message возвращает текст ошибки на указанном языке, если это ошибка валидации
+func Message(err error, locale i18n.Locale) string {
+	var validationErr *Error
+	if errors.As(err, &validationErr) {
+		return validationErr.Localize(locale)
 	}
+	return err.Error()
+}
+
+// ValidateProjectData проверяет все данные проекта
+func ValidateProjectData(data *models.ProjectData) models.ValidationState {
+	return ValidateProjectDataIn(i18n.DefaultLocale, data)
+}
 
-	// Валидация дедлайна
-	if err := validateDeadline(data.Deadline); err != nil {
-		state.Errors["deadline"] = err.Error()
+// ValidateProjectDataIn проверяет все данные проекта и формирует сообщения на указанном языке
+func ValidateProjectDataIn(locale i18n.Locale, data *models.ProjectData) models.ValidationState {
+	state := models.ValidationState{
+		Errors:       make(map[string]string),
+		ErrorCodes:   make(map[string]string),
+		Warnings:     make(map[string]string),
+		WarningCodes: make(map[string][]string),
+	}
+	addError := func(path string, err error) {
+		var validationErr *Error
+		if errors.As(err, &validationErr) && validationErr.Field != "" {
+			path += "." + validationErr.Field
+		}
+		state.Errors[path] = Message(err, locale)
+		state.ErrorCodes[path] = Code(err)
 	}
 
-	// Валидация приоритета
-	if err := validatePriority(data.Priority); err != nil {
-		state.Errors["priority"] = err.Error()
+	for _, field := range []string{"name", "description", "deadline", "priority", "budget", "spent", "status", "confidentiality"} {
+		if err := stepRules[field](data); err != nil {
+			addError(field, err)
+		}
 	}
 
 	// Валидация команды
-	if len(data.Team) > 0 {
-		for i, member := range data.Team {
-			if err := validateTeamMember(member); err != nil {
-				state.Errors[fmt.Sprintf("team[%d]", i)] = err.Error()
-			}
+	for i, member := range data.Team {
+		if err := validateTeamMember(member); err != nil {
+			addError(fmt.Sprintf("team[%d]", i), err)
 		}
 	}
 
+	if data.Plan != nil {
+		if err := ValidatePlan(data.Plan, data.Deadline, time.Now()); err != nil {
+			addError("plan", err)
+		}
+	}
+
+	addWarnings(locale, &state, data)
+
 	state.IsValid = len(state.Errors) == 0
 	return state
 }
 
 func validateName(name string) error {
+	policy := Policy()
 	name = strings.TrimSpace(name)
-	if len(name) < MinNameLength {
-		return fmt.Errorf("название должно содержать минимум %d символа", MinNameLength)
+	if policy.Length(name) < MinNameLength {
+		return NewError("name_too_short", MinNameLength)
 	}
-	if len(name) > MaxNameLength {
-		return fmt.Errorf("название не может быть длиннее %d символов", MaxNameLength)
+	if policy.Length(name) > MaxNameLength {
+		return NewError("name_too_long", MaxNameLength)
 	}
-	if !nameRegex.MatchString(name) {
-		return fmt.Errorf("название может содержать только буквы, цифры, пробелы, тире и подчеркивания")
+	if !policy.NamePattern.MatchString(name) || !hasLetterOrDigit(name) {
+		return NewError("name_invalid_chars", policy.NameSymbols)
 	}
 	return nil
 }
 
 func validateDescription(description string) error {
 	description = strings.TrimSpace(description)
-	if len(description) < MinDescriptionLength {
-		return fmt.Errorf("описание должно содержать минимум %d символов", MinDescriptionLength)
+	if TextLength(description) < MinDescriptionLength {
+		return NewError("description_too_short", MinDescriptionLength)
 	}
-	if len(description) > MaxDescriptionLength {
-		return fmt.Errorf("описание не может быть длиннее %d символов", MaxDescriptionLength)
+	if TextLength(description) > MaxDescriptionLength {
+		return NewError("description_too_long", MaxDescriptionLength)
 	}
 	return nil
 }
 
 func validateDeadline(deadline string) error {
 	if deadline == "" {
-		return fmt.Errorf("дедлайн обязателен")
+		return NewError("deadline_required")
 	}
 
 	date, err := time.Parse("02.01.2006", deadline)
 	if err != nil {
-		return fmt.Errorf("неверный формат даты, используйте ДД.ММ.ГГГГ")
+		return NewError("deadline_invalid_format")
 	}
 
 	if date.Before(time.Now()) {
-		return fmt.Errorf("дата не может быть в прошлом")
+		return NewError("deadline_in_past")
 	}
 
 	return nil
 }
 
 func validatePriority(priority string) error {
-	priority = strings.ToUpper(priority)
-	validPriorities := map[string]bool{
-		"ВЫСОКИЙ": true,
-		"СРЕДНИЙ": true,
-		"НИЗКИЙ":  true,
+	if !contains(Priorities, strings.ToUpper(priority)) {
+		return NewError("priority_invalid")
+	}
+	return nil
+}
+
+func validateAmount(code, amount string) error {
+	if amount == "" {
+		return nil
 	}
 
-	if !validPriorities[priority] {
-		return fmt.Errorf("некорректный приоритет, допустимые значения: ВЫСОКИЙ, СРЕДНИЙ, НИЗКИЙ")
+	if !amountRegex.MatchString(amount) {
+		return NewError(code)
 	}
 
 	return nil
 }
 
-func validateTeamMember(member models.TeamMember) error {
-	if !emailRegex.MatchString(member.Email) {
-		return fmt.Errorf("некорректный email: %s", member.Email)
+func validateStatus(status string) error {
+	if status == "" || contains(ProjectStatuses, status) {
+		return nil
+	}
+	return NewError("status_invalid")
+}
+
+func validateConfidentiality(confidentiality string) error {
+	if confidentiality == "" || contains(ConfidentialityLevels, confidentiality) {
+		return nil
+	}
+	return NewError("confidentiality_invalid")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+	return false
+}
 
-	validRoles := map[string]bool{
-		"MANAGER": true,
-		"EDITOR":  true,
-		"READER":  true,
+func validateTeamMember(member models.TeamMember) error {
+	if !emailRegex.MatchString(member.Email) {
+		return fieldError("email", "member_email_invalid", member.Email)
 	}
 
-	if !validRoles[member.Role] {
-		return fmt.Errorf("некорректная роль для %s, допустимые значения: MANAGER, EDITOR, READER", member.Email)
+	if !contains(Roles, member.Role) {
+		return fieldError("role", "member_role_invalid", member.Email)
 	}
 
 	if strings.TrimSpace(member.Name) == "" {
-		return fmt.Errorf("имя участника обязательно")
+		return fieldError("name", "member_name_required")
 	}
 
 	if strings.TrimSpace(member.Lastname) == "" {
-		return fmt.Errorf("фамилия участника обязательна")
+		return fieldError("lastname", "member_lastname_required")
 	}
 
 	return nil
 }
 
-// ValidateProjectStep проверяет данные конкретного шага
-func ValidateProjectStep(step string, data *models.ProjectData) error {
-	switch step {
-	case "name":
-		return validateName(data.Name)
-	case "description":
-		return validateDescription(data.Description)
-	case "deadline":
-		return validateDeadline(data.Deadline)
-	case "priority":
-		return validatePriority(data.Priority)
-	case "team":
-		if len(data.Team) > 0 {
-			for _, member := range data.Team {
-				if err := validateTeamMember(member); err != nil {
-					return err
-				}
+// stepRules содержит правила проверки отдельных шагов
+var stepRules = map[string]func(data *models.ProjectData) error{
+	"name":            func(data *models.ProjectData) error { return validateName(data.Name) },
+	"description":     func(data *models.ProjectData) error { return validateDescription(data.Description) },
+	"deadline":        func(data *models.ProjectData) error { return validateDeadline(data.Deadline) },
+	"priority":        func(data *models.ProjectData) error { return validatePriority(data.Priority) },
+	"budget":          func(data *models.ProjectData) error { return validateAmount("budget_invalid_format", data.Budget) },
+	"spent":           func(data *models.ProjectData) error { return validateAmount("spent_invalid_format", data.Spent) },
+	"status":          func(data *models.ProjectData) error { return validateStatus(data.Status) },
+	"confidentiality": func(data *models.ProjectData) error { return validateConfidentiality(data.Confidentiality) },
+	"team": func(data *models.ProjectData) error {
+		for _, member := range data.Team {
+			if err := validateTeamMember(member); err != nil {
+				return err
 			}
 		}
 		return nil
-	default:
-		return fmt.Errorf("неизвестный шаг создания проекта: %s", step)
+	},
+}
+
+// HasStepRule сообщает, есть ли правило проверки для шага
+func HasStepRule(step string) bool {
+	_, ok := stepRules[step]
+	return ok
+}
+
+// ValidateProjectStep проверяет данные конкретного шага
+func ValidateProjectStep(step string, data *models.ProjectData) error {
+	rule, ok := stepRules[step]
+	if !ok {
+		return NewError("unknown_step", step)
 	}
+	return rule(data)
 }
@@ -0,0 +1,218 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/project/calendar"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/i18n"
+	"github.com/Jamolkhon5/mistral/internal/ai/project/models"
+)
+
+// DeadlineSoonWorkingDays - количество рабочих дней, меньше которого дедлайн считается слишком близким
+const DeadlineSoonWorkingDays = 5
+
+// Warning - мягкое предупреждение о данных проекта. В отличие от ошибок не мешает
+// перейти к следующему шагу или создать проект.
+type Warning struct {
+	// Field - путь поля, к которому относится предупреждение
+	Field string
+	Code  string
+	Args  []interface{}
+}
+
+// Localize возвращает текст предупреждения на указанном языке
+func (w Warning) Localize(locale i18n.Locale) string {
+	return i18n.T(locale, "warning."+w.Code, w.Args...)
+}
+
+// WarningRule проверяет данные проекта и возвращает предупреждение или nil
+type WarningRule func(data *models.ProjectData, now time.Time) *Warning
+
+var (
+	warningMu    sync.RWMutex
+	warningRules = []WarningRule{
+		deadlineSoonRule,
+		deadlineNonWorkingDayRule,
+		deadlineFeasibilityRule,
+		descriptionGoalsRule,
+		highPriorityTeamRule,
+		spentOverBudgetRule,
+	}
+
+	// goalWords - корни слов, по которым видно, что в описании есть цели или ожидаемые результаты
+	goalWords = []string{
+		"цел", "задач", "результат", "итог", "метрик", "kpi",
+		"goal", "objective", "result", "outcome", "deliverable", "aim",
+	}
+)
+
+// RegisterWarningRule добавляет правило предупреждений. Правила выполняются в порядке регистрации.
+func RegisterWarningRule(rule WarningRule) {
+	warningMu.Lock()
+	defer warningMu.Unlock()
+	warningRules = append(warningRules, rule)
+}
+
+// CheckWarnings выполняет все правила и возвращает предупреждения в порядке регистрации правил
+func CheckWarnings(data *models.ProjectData, now time.Time) []Warning {
+	warningMu.RLock()
+	rules := warningRules
+	warningMu.RUnlock()
+
+	var warnings []Warning
+	for _, rule := range rules {
+		if warning := rule(data, now); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings
+}
+
+// FieldWarnings возвращает тексты предупреждений, относящихся к полю
+func FieldWarnings(locale i18n.Locale, data *models.ProjectData, field string) []string {
+	var messages []string
+	for _, warning := range CheckWarnings(data, time.Now()) {
+		if warning.Field == field {
+			messages = append(messages, warning.Localize(locale))
+		}
+	}
+	return messages
+}
+
+// addWarnings заполняет предупреждения состояния валидации. Несколько предупреждений
+// одного поля объединяются в одно сообщение.
+func addWarnings(locale i18n.Locale, state *models.ValidationState, data *models.ProjectData) {
+	for _, warning := range CheckWarnings(data, time.Now()) {
+		AddWarning(locale, state, warning)
+	}
+}
+
+// AddWarning добавляет предупреждение в состояние валидации. Используется для проверок,
+// которым нужны внешние данные, например список проектов пользователя.
+func AddWarning(locale i18n.Locale, state *models.ValidationState, warning Warning) {
+	message := warning.Localize(locale)
+	if previous, ok := state.Warnings[warning.Field]; ok {
+		message = previous + " " + message
+	}
+	if state.Warnings == nil {
+		state.Warnings = make(map[string]string)
+	}
+	if state.WarningCodes == nil {
+		state.WarningCodes = make(map[string][]string)
+	}
+	state.Warnings[warning.Field] = message
+	state.WarningCodes[warning.Field] = append(state.WarningCodes[warning.Field], warning.Code)
+}
+
+func deadlineSoonRule(data *models.ProjectData, now time.Time) *Warning {
+	deadline, err := time.Parse("02.01.2006", data.Deadline)
+	if err != nil || deadline.Before(now) {
+		return nil
+	}
+	if calendar.Default().WorkingDaysBetween(now, deadline) < DeadlineSoonWorkingDays {
+		return &Warning{Field: "deadline", Code: "deadline_soon", Args: []interface{}{DeadlineSoonWorkingDays}}
+	}
+	return nil
+}
+
+func deadlineNonWorkingDayRule(data *models.ProjectData, now time.Time) *Warning {
+	deadline, err := time.Parse("02.01.2006", data.Deadline)
+	if err != nil || deadline.Before(now) {
+		return nil
+	}
+	if !calendar.Default().IsWorkingDay(deadline) {
+		return &Warning{Field: "deadline", Code: "deadline_non_working_day", Args: []interface{}{data.Deadline}}
+	}
+	return nil
+}
+
+// deadlineFeasibilityRule сравнивает оценку трудоемкости с рабочими днями команды до дедлайна.
+// Если оценка не указана явно, используется сумма оценок задач плана.
+func deadlineFeasibilityRule(data *models.ProjectData, now time.Time) *Warning {
+	deadline, err := time.Parse("02.01.2006", data.Deadline)
+	if err != nil || deadline.Before(now) {
+		return nil
+	}
+
+	effort := EffortDays(data)
+	if effort <= 0 {
+		return nil
+	}
+
+	workCalendar := calendar.Default()
+	if workCalendar.IsFeasible(now, deadline, effort, len(data.Team)) {
+		return nil
+	}
+	return &Warning{
+		Field: "deadline",
+		Code:  "deadline_infeasible",
+		Args:  []interface{}{strconv.FormatFloat(effort, 'f', -1, 64), workCalendar.WorkingDaysBetween(now, deadline)},
+	}
+}
+
+// EffortDays возвращает оценку трудоемкости проекта в человеко-днях: указанную явно
+// или сумму оценок задач плана
+func EffortDays(data *models.ProjectData) float64 {
+	if data.EffortDays > 0 || data.Plan == nil {
+		return data.EffortDays
+	}
+	var total float64
+	for _, milestone := range data.Plan.Milestones {
+		for _, task := range milestone.Tasks {
+			total += task.EstimateDays
+		}
+	}
+	return total
+}
+
+func descriptionGoalsRule(data *models.ProjectData, now time.Time) *Warning {
+	description := strings.ToLower(strings.TrimSpace(data.Description))
+	if description == "" {
+		return nil
+	}
+	for _, word := range goalWords {
+		if strings.Contains(description, word) {
+			return nil
+		}
+	}
+	return &Warning{Field: "description", Code: "description_no_goals"}
+}
+
+func highPriorityTeamRule(data *models.ProjectData, now time.Time) *Warning {
+	if strings.ToUpper(data.Priority) == "ВЫСОКИЙ" && len(data.Team) == 0 {
+		return &Warning{Field: "team", Code: "high_priority_no_team"}
+	}
+	return nil
+}
+
+func spentOverBudgetRule(data *models.ProjectData, now time.Time) *Warning {
+	budget, budgetCurrency, ok := parseAmount(data.Budget)
+	if !ok {
+		return nil
+	}
+	spent, spentCurrency, ok := parseAmount(data.Spent)
+	// Суммы в разных валютах не сравниваем, курсов у ассистента нет
+	if !ok || spentCurrency != budgetCurrency {
+		return nil
+	}
+	if spent > budget {
+		return &Warning{Field: "spent", Code: "spent_over_budget", Args: []interface{}{data.Spent, data.Budget}}
+	}
+	return nil
+}
+
+// parseAmount разбирает сумму в формате "<сумма> <валюта>", который проверяет validateAmount
+func parseAmount(amount string) (float64, string, bool) {
+	matches := amountRegex.FindStringSubmatch(amount)
+	if matches == nil {
+		return 0, "", false
+	}
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return value, matches[2], true
+}
@@ -0,0 +1,215 @@
+package wizard
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/template"
+)
+
+//go:embed project_flow.json
+var defaultProjectFlow []byte
+
+// Типы ввода, по которым клиент выбирает виджет для шага
+const (
+	InputText     = "text"     // Однострочный текст
+	InputTextarea = "textarea" // Многострочный текст
+	InputDate     = "date"     // Дата в формате ДД.ММ.ГГГГ
+	InputEnum     = "enum"     // Выбор одного из вариантов
+	InputAmount   = "amount"   // Сумма с валютой
+	InputEmail    = "email"    // Email или ID пользователя
+	InputConfirm  = "confirm"  // Подтверждение да/нет
+)
+
+var inputTypes = map[string]bool{
+	InputText: true, InputTextarea: true, InputDate: true, InputEnum: true,
+	InputAmount: true, InputEmail: true, InputConfirm: true,
+}
+
+// Step описывает один шаг мастера
+type Step struct {
+	ID        string `json:"id"`                   // Идентификатор шага
+	Field     string `json:"field,omitempty"`      // Поле ProjectData, которое заполняет шаг (без handler - только поля ProjectData.SetField)
+	PromptKey string `json:"prompt_key,omitempty"` // Ключ подсказки шага из каталога prompts
+	Prompt    string `json:"prompt,omitempty"`     // Шаблон подсказки (text/template), если ключ не задан
+	ErrorKey  string `json:"error_key,omitempty"`  // Ключ подсказки после ошибки валидации в каталоге сообщений
+	ErrorHint string `json:"error_hint,omitempty"` // Подсказка после ошибки валидации, если ключ не задан
+	Parser    string `json:"parser,omitempty"`     // Имя парсера пользовательского ввода
+	Validator string `json:"validator,omitempty"`  // Имя правила валидации поля
+	Handler   string `json:"handler,omitempty"`    // Имя специального обработчика для сложных шагов
+	Input     string `json:"input,omitempty"`      // Тип ввода для клиента (InputText, InputDate, ...), по умолчанию InputText
+	Optional  bool   `json:"optional,omitempty"`   // Шаг можно пропустить
+	Next      string `json:"next,omitempty"`       // Следующий шаг после успешного ввода
+	OnSkip    string `json:"on_skip,omitempty"`    // Следующий шаг при пропуске, по умолчанию Next
+}
+
+// Definition описывает последовательность шагов мастера
+type Definition struct {
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	Steps []Step `json:"steps"`
+
+	index map[string]*Step
+	path  []string // Основная последовательность шагов от Start по Next
+}
+
+// Registry сообщает, какие парсеры, валидаторы, обработчики, поля и тексты доступны
+// исполнителю мастера
+type Registry interface {
+	HasParser(name string) bool
+	HasValidator(name string) bool
+	HasHandler(name string) bool
+	HasField(name string) bool  // Поле, которое общий обработчик шага умеет заполнить
+	HasPrompt(key string) bool  // Подсказка шага в каталоге prompts
+	HasMessage(key string) bool // Сообщение в каталоге i18n
+}
+
+// LoadProjectFlow загружает описание мастера создания проекта из файла.
+// Если путь не указан, используется встроенное описание.
+func LoadProjectFlow(path string) (*Definition, error) {
+	if path == "" {
+		return Parse(defaultProjectFlow)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения описания мастера %s: %w", path, err)
+	}
+	return Parse(data)
+}
+
+// Parse разбирает описание мастера в формате JSON и проверяет связность шагов
+func Parse(data []byte) (*Definition, error) {
+	var def Definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("ошибка разбора описания мастера: %w", err)
+	}
+
+	def.index = make(map[string]*Step, len(def.Steps))
+	for i := range def.Steps {
+		step := &def.Steps[i]
+		if step.ID == "" {
+			return nil, fmt.Errorf("шаг %d: не указан id", i)
+		}
+		if _, exists := def.index[step.ID]; exists {
+			return nil, fmt.Errorf("шаг %s объявлен повторно", step.ID)
+		}
+		if step.Field == "" && step.Handler == "" {
+			return nil, fmt.Errorf("шаг %s: нужно указать field или handler", step.ID)
+		}
+		if step.OnSkip == "" {
+			step.OnSkip = step.Next
+		}
+		if step.Input == "" {
+			step.Input = InputText
+		}
+		if !inputTypes[step.Input] {
+			return nil, fmt.Errorf("шаг %s: неизвестный тип ввода %q", step.ID, step.Input)
+		}
+		def.index[step.ID] = step
+	}
+
+	if _, ok := def.index[def.Start]; !ok {
+		return nil, fmt.Errorf("начальный шаг %q не найден", def.Start)
+	}
+
+	for _, step := range def.Steps {
+		for _, target := range []string{step.Next, step.OnSkip} {
+			if target == "" {
+				continue
+			}
+			if _, ok := def.index[target]; !ok {
+				return nil, fmt.Errorf("шаг %s ссылается на неизвестный шаг %q", step.ID, target)
+			}
+		}
+		if step.Prompt != "" {
+			if _, err := template.New(step.ID).Parse(step.Prompt); err != nil {
+				return nil, fmt.Errorf("шаг %s: ошибка в шаблоне подсказки: %w", step.ID, err)
+			}
+		}
+	}
+
+	def.path = def.mainPath()
+	return &def, nil
+}
+
+// mainPath проходит шаги от Start по Next. Количество переходов ограничено числом шагов,
+// чтобы цикл в описании не зациклил разбор.
+func (d *Definition) mainPath() []string {
+	var path []string
+	visited := make(map[string]bool, len(d.Steps))
+	for id := d.Start; id != "" && !visited[id]; id = d.index[id].Next {
+		visited[id] = true
+		path = append(path, id)
+	}
+	return path
+}
+
+// Check проверяет, что все парсеры, валидаторы, обработчики, поля и ключи текстов из описания
+// известны исполнителю. Опечатка в ключе иначе обнаружилась бы только пустой подсказкой в диалоге.
+func (d *Definition) Check(registry Registry) error {
+	for _, step := range d.Steps {
+		if step.Parser != "" && !registry.HasParser(step.Parser) {
+			return fmt.Errorf("шаг %s: неизвестный парсер %q", step.ID, step.Parser)
+		}
+		if step.Validator != "" && !registry.HasValidator(step.Validator) {
+			return fmt.Errorf("шаг %s: неизвестный валидатор %q", step.ID, step.Validator)
+		}
+		if step.Handler != "" && !registry.HasHandler(step.Handler) {
+			return fmt.Errorf("шаг %s: неизвестный обработчик %q", step.ID, step.Handler)
+		}
+		if step.Handler == "" && !registry.HasField(step.Field) {
+			return fmt.Errorf("шаг %s: поле %q нельзя заполнить без специального обработчика", step.ID, step.Field)
+		}
+		if step.PromptKey != "" && !registry.HasPrompt(step.PromptKey) {
+			return fmt.Errorf("шаг %s: неизвестный ключ подсказки %q", step.ID, step.PromptKey)
+		}
+		if step.ErrorKey != "" && !registry.HasMessage(step.ErrorKey) {
+			return fmt.Errorf("шаг %s: неизвестный ключ подсказки об ошибке %q", step.ID, step.ErrorKey)
+		}
+	}
+	return nil
+}
+
+// Step возвращает шаг по идентификатору
+func (d *Definition) Step(id string) (*Step, bool) {
+	step, ok := d.index[id]
+	return step, ok
+}
+
+// StepForField возвращает первый шаг, заполняющий указанное поле
+func (d *Definition) StepForField(field string) (*Step, bool) {
+	for i := range d.Steps {
+		if d.Steps[i].Field == field || d.Steps[i].ID == field {
+			return &d.Steps[i], true
+		}
+	}
+	return nil, false
+}
+
+// RenderPrompt подставляет данные в шаблон подсказки шага
+func (s *Step) RenderPrompt(data interface{}) (string, error) {
+	tmpl, err := template.New(s.ID).Parse(s.Prompt)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Position возвращает номер шага в основной последовательности, начиная с 1, и длину
+// последовательности. Для шагов вне нее (например, доступных только при правке) номер равен 0.
+func (d *Definition) Position(id string) (index, total int) {
+	for i, stepID := range d.path {
+		if stepID == id {
+			return i + 1, len(d.path)
+		}
+	}
+	return 0, len(d.path)
+}
@@ -0,0 +1,174 @@
+package wizard
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRegistry знает только перечисленные имена и ключи
+type fakeRegistry struct {
+	parsers, validators, handlers, fields, prompts, messages map[string]bool
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		parsers:    map[string]bool{"text": true},
+		validators: map[string]bool{"name": true},
+		handlers:   map[string]bool{"confirmation": true},
+		fields:     map[string]bool{"name": true},
+		prompts:    map[string]bool{"name": true},
+		messages:   map[string]bool{"error.name": true},
+	}
+}
+
+func (r *fakeRegistry) HasParser(name string) bool    { return r.parsers[name] }
+func (r *fakeRegistry) HasValidator(name string) bool { return r.validators[name] }
+func (r *fakeRegistry) HasHandler(name string) bool   { return r.handlers[name] }
+func (r *fakeRegistry) HasField(name string) bool     { return r.fields[name] }
+func (r *fakeRegistry) HasPrompt(key string) bool     { return r.prompts[key] }
+func (r *fakeRegistry) HasMessage(key string) bool    { return r.messages[key] }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		flow    string
+		wantErr string
+	}{
+		{
+			"корректное описание",
+			`{"start":"name","steps":[{"id":"name","field":"name","next":"confirmation"},{"id":"confirmation","handler":"confirmation"}]}`,
+			"",
+		},
+		{"некорректный JSON", `{"start":`, "ошибка разбора"},
+		{"шаг без id", `{"start":"name","steps":[{"field":"name"}]}`, "не указан id"},
+		{
+			"повторный id",
+			`{"start":"name","steps":[{"id":"name","field":"name"},{"id":"name","field":"description"}]}`,
+			"объявлен повторно",
+		},
+		{"шаг без field и handler", `{"start":"name","steps":[{"id":"name"}]}`, "field или handler"},
+		{"неизвестный тип ввода", `{"start":"name","steps":[{"id":"name","field":"name","input":"slider"}]}`, "неизвестный тип ввода"},
+		{"нет начального шага", `{"start":"intro","steps":[{"id":"name","field":"name"}]}`, "начальный шаг"},
+		{"не указан начальный шаг", `{"steps":[{"id":"name","field":"name"}]}`, "начальный шаг"},
+		{"неизвестный next", `{"start":"name","steps":[{"id":"name","field":"name","next":"budget"}]}`, `неизвестный шаг "budget"`},
+		{
+			"неизвестный on_skip",
+			`{"start":"name","steps":[{"id":"name","field":"name","optional":true,"on_skip":"team"}]}`,
+			`неизвестный шаг "team"`,
+		},
+		{"ошибка в шаблоне", `{"start":"name","steps":[{"id":"name","field":"name","prompt":"{{.Name"}]}`, "ошибка в шаблоне"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.flow))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Parse: unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	def, err := Parse([]byte(`{"start":"name","steps":[{"id":"name","field":"name","next":"done"},{"id":"done","handler":"confirmation"}]}`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	step, ok := def.Step("name")
+	if !ok {
+		t.Fatal("step name not found")
+	}
+	if step.OnSkip != "done" {
+		t.Errorf("OnSkip = %q, want Next %q", step.OnSkip, "done")
+	}
+	if step.Input != InputText {
+		t.Errorf("Input = %q, want %q", step.Input, InputText)
+	}
+}
+
+func TestMainPath(t *testing.T) {
+	tests := []struct {
+		name string
+		flow string
+		want []string
+	}{
+		{
+			"линейный",
+			`{"start":"a","steps":[{"id":"a","field":"name","next":"b"},{"id":"b","field":"name","next":"c"},{"id":"c","handler":"confirmation"}]}`,
+			[]string{"a", "b", "c"},
+		},
+		{
+			"шаг вне основного пути",
+			`{"start":"a","steps":[{"id":"a","field":"name","next":"c"},{"id":"b","field":"name"},{"id":"c","handler":"confirmation"}]}`,
+			[]string{"a", "c"},
+		},
+		{
+			"цикл по next",
+			`{"start":"a","steps":[{"id":"a","field":"name","next":"b"},{"id":"b","field":"name","next":"a"}]}`,
+			[]string{"a", "b"},
+		},
+		{
+			"шаг ссылается на себя",
+			`{"start":"a","steps":[{"id":"a","field":"name","next":"a"}]}`,
+			[]string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := Parse([]byte(tt.flow))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := def.mainPath(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mainPath() = %v, want %v", got, tt.want)
+			}
+			if index, total := def.Position(tt.want[len(tt.want)-1]); index != len(tt.want) || total != len(tt.want) {
+				t.Errorf("Position(%q) = %d, %d, want %d, %d", tt.want[len(tt.want)-1], index, total, len(tt.want), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    string
+		wantErr string
+	}{
+		{"известные имена", `{"id":"name","field":"name","parser":"text","validator":"name","prompt_key":"name","error_key":"error.name"}`, ""},
+		{"известный обработчик", `{"id":"name","handler":"confirmation"}`, ""},
+		{"неизвестный парсер", `{"id":"name","field":"name","parser":"number"}`, "неизвестный парсер"},
+		{"неизвестный валидатор", `{"id":"name","field":"name","validator":"budget"}`, "неизвестный валидатор"},
+		{"неизвестный обработчик", `{"id":"name","handler":"team"}`, "неизвестный обработчик"},
+		{"поле без обработчика", `{"id":"name","field":"team"}`, "без специального обработчика"},
+		{"неизвестный prompt_key", `{"id":"name","field":"name","prompt_key":"nmae"}`, "неизвестный ключ подсказки"},
+		{"неизвестный error_key", `{"id":"name","field":"name","error_key":"error.nmae"}`, "неизвестный ключ подсказки об ошибке"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := Parse([]byte(`{"start":"name","steps":[` + tt.step + `]}`))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			err = def.Check(newFakeRegistry())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Check: unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Check error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/task/models"
+)
+
+// DefaultBaseURL используется, если адрес сервиса задач не задан в конфигурации
+const DefaultBaseURL = "http://task-service:5641"
+
+// requestTimeout меньше WriteTimeout HTTP-сервера (15 с), чтобы ответ об ошибке вместе
+// с ключом идемпотентности успел дойти до клиента
+const requestTimeout = 10 * time.Second
+
+// Client отправляет запросы к сервису задач
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// CreateTask создает задачу от имени пользователя и возвращает ее идентификатор.
+// authorization - заголовок Authorization исходного запроса пользователя. creationKey передается
+// в заголовке Idempotency-Key, поэтому повторное подтверждение не создает дубликат.
+func (c *Client) CreateTask(ctx context.Context, authorization, creationKey string, task *models.TaskData) (string, error) {
+	taskRequest := map[string]interface{}{
+		"title":          task.Title,
+		"description":    task.Description,
+		"due_date":       task.DueDate,
+		"estimate_hours": task.EstimateHours,
+		"priority":       task.Priority,
+		"project_id":     task.ProjectID,
+	}
+	if task.Assignee != nil {
+		taskRequest["assignee_id"] = task.Assignee.ID
+	}
+
+	jsonData, err := json.Marshal(taskRequest)
+	if err != nil {
+		return "", fmt.Errorf("ошибка при маршалинге задачи: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/tasks", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("ошибка при создании запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authorization)
+	if creationKey != "" {
+		req.Header.Set("Idempotency-Key", creationKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("ошибка при отправке запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("сервис задач вернул статус %d: %s", resp.StatusCode, string(body))
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &created); err != nil {
+			return "", fmt.Errorf("ошибка при декодировании ответа: %w", err)
+		}
+	}
+
+	return created.ID, nil
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/task/client"
+	"github.com/Jamolkhon5/mistral/internal/ai/task/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/task/service"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+)
+
+type TaskAssistantHandler struct {
+	assistant *service.TaskAssistant
+	tasks     *client.Client
+}
+
+func NewTaskAssistantHandler(tasks *client.Client) *TaskAssistantHandler {
+	return &TaskAssistantHandler{
+		assistant: service.NewTaskAssistant(),
+		tasks:     tasks,
+	}
+}
+
+func (h *TaskAssistantHandler) ChatWithAssistant(w http.ResponseWriter, r *http.Request) {
+	// Проверка авторизации
+	userID, err := auth.VerifyToken(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.AssistantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Обработка сообщения ассистентом
+	response, err := h.assistant.HandleMessage(r.Context(), userID, req.Message, req.Context)
+	if err != nil {
+		log.Printf("Error handling task message: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Создаем задачу в сервисе задач от имени пользователя
+	if response.SuggestedAction == "create_task" {
+		taskContext := &response.TaskContext
+		taskID, err := h.tasks.CreateTask(r.Context(), r.Header.Get("Authorization"), taskContext.CreationKey, taskContext.TaskData)
+		if err != nil {
+			// Контекст с ключом идемпотентности возвращается клиенту: повторное "да" отправит тот же
+			// ключ, и задача, которую сервис задач все-таки создал, не будет создана второй раз
+			log.Printf("Error creating task: %v", err)
+			response.Message = "❌ Не удалось создать задачу: сервис задач временно недоступен. Данные сохранены — ответьте \"да\", чтобы повторить."
+			response.SuggestedAction = "task_creation_failed"
+			response.Error = err.Error()
+			writeResponse(w, http.StatusBadGateway, response)
+			return
+		}
+		response.TaskID = taskID
+		// Диалог завершен: повторное "да" в этом контексте задачу не создает
+		taskContext.TaskID = taskID
+		taskContext.CurrentStep = "created"
+	}
+
+	writeResponse(w, http.StatusOK, response)
+}
+
+func writeResponse(w http.ResponseWriter, status int, response *models.AssistantResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding task response: %v", err)
+	}
+}
+
+// RegisterRoutes регистрирует маршруты для AI-ассистента задач
+func (h *TaskAssistantHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/ai/task/chat", h.ChatWithAssistant)
+}
@@ -0,0 +1,52 @@
+package models
+
+// AssistantRequest представляет запрос к AI-ассистенту задач
+type AssistantRequest struct {
+	Message string               `json:"message"`
+	Context *TaskCreationContext `json:"context,omitempty"`
+}
+
+// TaskCreationContext содержит контекст создания задачи
+type TaskCreationContext struct {
+	CurrentStep     string          `json:"current_step"`           // Текущий шаг создания задачи
+	TaskData        *TaskData       `json:"task_data"`              // Данные задачи
+	ValidationState ValidationState `json:"validation_state"`       // Состояние валидации
+	CreationKey     string          `json:"creation_key,omitempty"` // Ключ идемпотентности создания задачи
+	TaskID          string          `json:"task_id,omitempty"`      // Созданная задача
+}
+
+// TaskData содержит данные задачи
+type TaskData struct {
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Assignee      *Assignee `json:"assignee,omitempty"`
+	DueDate       string    `json:"due_date"`
+	EstimateHours float64   `json:"estimate_hours"`
+	Priority      string    `json:"priority"`
+	ProjectID     string    `json:"project_id"`
+}
+
+// Assignee представляет исполнителя задачи
+type Assignee struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Lastname string `json:"lastname"`
+	Email    string `json:"email"`
+	Photo    string `json:"photo"`
+}
+
+// ValidationState содержит состояние валидации данных
+type ValidationState struct {
+	IsValid  bool              `json:"is_valid"`
+	Errors   map[string]string `json:"errors"`
+	Warnings map[string]string `json:"warnings"`
+}
+
+// AssistantResponse представляет ответ от AI-ассистента задач
+type AssistantResponse struct {
+	Message         string              `json:"message"`
+	TaskContext     TaskCreationContext `json:"task_context"`
+	SuggestedAction string              `json:"suggested_action"`
+	TaskID          string              `json:"task_id,omitempty"`
+	Error           string              `json:"error,omitempty"`
+}
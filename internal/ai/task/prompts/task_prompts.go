@@ -0,0 +1,96 @@
+package prompts
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/task/models"
+)
+
+const (
+	WelcomeMessage = `Здравствуйте! Я помогу вам создать задачу.
+
+Как назовем задачу? Название должно кратко описывать, что нужно сделать.`
+
+	DescriptionPrompt = `Добавьте описание задачи: что именно нужно сделать и каким должен быть результат.
+
+Напишите "пропустить", если описание не нужно.`
+
+	AssigneePrompt = `Кто будет исполнителем?
+
+Введите ID пользователя, напишите "я", чтобы назначить задачу на себя, или "пропустить", чтобы оставить задачу без исполнителя.`
+
+	DueDatePrompt = `Укажите срок выполнения задачи.
+
+• Формат: ДД.ММ.ГГГГ
+• Можно написать "сегодня" или "завтра"`
+
+	EstimatePrompt = `Сколько времени займет задача?
+
+Например: "4 часа", "2д", "90 минут", "1.5h". Рабочий день считается за 8 часов.
+Напишите "пропустить", если оценки пока нет.`
+
+	PriorityPrompt = `Выберите приоритет задачи:
+
+🔴 ВЫСОКИЙ
+🟡 СРЕДНИЙ
+🟢 НИЗКИЙ
+
+Напишите "высокий", "средний" или "низкий".`
+
+	ProjectPrompt = `К какому проекту относится задача? Введите ID проекта или напишите "пропустить".`
+
+	ConfirmationPrompt = `Давайте проверим данные задачи:
+
+%s
+
+Всё верно? Ответьте "да" для создания задачи или "нет", чтобы начать заново.`
+)
+
+// GetTaskDataSummary форматирует данные задачи для подтверждения
+func GetTaskDataSummary(data *models.TaskData) string {
+	return fmt.Sprintf(`
+📌 Название: %s
+
+📝 Описание: %s
+
+👤 Исполнитель: %s
+
+📅 Срок: %s
+
+⏱ Оценка: %s
+
+⚡ Приоритет: %s
+
+📁 Проект: %s
+`,
+		data.Title,
+		valueOrDash(data.Description),
+		formatAssignee(data.Assignee),
+		data.DueDate,
+		formatEstimate(data.EstimateHours),
+		data.Priority,
+		valueOrDash(data.ProjectID),
+	)
+}
+
+func formatAssignee(assignee *models.Assignee) string {
+	if assignee == nil {
+		return "Не назначен"
+	}
+	return fmt.Sprintf("%s %s (%s)", assignee.Name, assignee.Lastname, assignee.Email)
+}
+
+func formatEstimate(hours float64) string {
+	if hours == 0 {
+		return "Не указана"
+	}
+	return strconv.FormatFloat(hours, 'f', -1, 64) + " ч"
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "—"
+	}
+	return value
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/task/models"
+	"github.com/Jamolkhon5/mistral/internal/ai/task/prompts"
+	"github.com/Jamolkhon5/mistral/internal/ai/task/validator"
+	"github.com/Jamolkhon5/mistral/internal/auth"
+	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
+)
+
+// hoursPerDay используется для перевода оценки в днях в часы
+const hoursPerDay = 8
+
+var (
+	dateRegex     = regexp.MustCompile(`(\d{2})[-./](\d{2})[-./](\d{4})`)
+	estimateRegex = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*([a-zа-яё]*)`)
+	userIDRegex   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|\b\d+\b`)
+
+	skipWords = map[string]bool{"пропустить": true, "пропусти": true, "нет": true, "-": true}
+	selfWords = map[string]bool{"я": true, "мне": true, "на меня": true, "себе": true}
+
+	// Порядок важен: "несрочн" нужно проверить раньше, чем "срочн"
+	priorityWords = []struct{ stem, priority string }{
+		{"несрочн", "НИЗКИЙ"}, {"низк", "НИЗКИЙ"},
+		{"высок", "ВЫСОКИЙ"}, {"срочн", "ВЫСОКИЙ"}, {"критичн", "ВЫСОКИЙ"},
+		{"средн", "СРЕДНИЙ"}, {"обычн", "СРЕДНИЙ"},
+	}
+)
+
+type TaskAssistant struct{}
+
+func NewTaskAssistant() *TaskAssistant {
+	return &TaskAssistant{}
+}
+
+// HandleMessage обрабатывает сообщение пользователя и возвращает ответ ассистента задач.
+// ctx ограничивает запросы к сервису авторизации временем обработки запроса.
+func (ta *TaskAssistant) HandleMessage(ctx context.Context, userID, userMessage string, context *models.TaskCreationContext) (*models.AssistantResponse, error) {
+	// Если контекст не определен или пустой, инициализируем новый
+	if context == nil || context.CurrentStep == "" {
+		context = &models.TaskCreationContext{
+			CurrentStep: "title",
+			TaskData: &models.TaskData{
+				Priority: "СРЕДНИЙ",
+			},
+		}
+		return &models.AssistantResponse{
+			Message:     prompts.WelcomeMessage,
+			TaskContext: *context,
+		}, nil
+	}
+
+	log.Printf("Обработка шага задачи: %s с сообщением: %s", context.CurrentStep, userMessage)
+
+	message := strings.TrimSpace(userMessage)
+	switch context.CurrentStep {
+	case "title":
+		context.TaskData.Title = message
+		return ta.completeStep(context, "title", "description", prompts.DescriptionPrompt), nil
+	case "description":
+		if !isSkip(message) {
+			context.TaskData.Description = message
+		}
+		return ta.completeStep(context, "description", "assignee", prompts.AssigneePrompt), nil
+	case "assignee":
+		return ta.handleAssigneeStep(ctx, userID, message, context), nil
+	case "due_date":
+		context.TaskData.DueDate = parseDueDate(message)
+		return ta.completeStep(context, "due_date", "estimate", prompts.EstimatePrompt), nil
+	case "estimate":
+		return ta.handleEstimateStep(message, context), nil
+	case "priority":
+		context.TaskData.Priority = parsePriority(message)
+		return ta.completeStep(context, "priority", "project", prompts.ProjectPrompt), nil
+	case "project":
+		if !isSkip(message) {
+			context.TaskData.ProjectID = message
+		}
+		return ta.completeStep(context, "project", "confirmation", ""), nil
+	case "confirmation":
+		return ta.handleConfirmationStep(message, context), nil
+	case "created":
+		// Повторное подтверждение после создания не должно создать вторую задачу
+		return &models.AssistantResponse{
+			Message:     "✅ Задача уже создана. Чтобы создать еще одну, начните новый диалог.",
+			TaskContext: *context,
+		}, nil
+	default:
+		return nil, fmt.Errorf("неизвестный шаг: %s", context.CurrentStep)
+	}
+}
+
+// completeStep проверяет данные шага и переходит к следующему
+func (ta *TaskAssistant) completeStep(context *models.TaskCreationContext, step, nextStep, prompt string) *models.AssistantResponse {
+	if err := validator.ValidateTaskStep(step, context.TaskData); err != nil {
+		return &models.AssistantResponse{
+			Message:     fmt.Sprintf("❌ %s\n\nПожалуйста, попробуйте еще раз.", err.Error()),
+			TaskContext: *context,
+		}
+	}
+
+	context.CurrentStep = nextStep
+	if nextStep == "confirmation" {
+		prompt = fmt.Sprintf(prompts.ConfirmationPrompt, prompts.GetTaskDataSummary(context.TaskData))
+	}
+	return &models.AssistantResponse{
+		Message:     prompt,
+		TaskContext: *context,
+	}
+}
+
+func (ta *TaskAssistant) handleAssigneeStep(ctx context.Context, userID, message string, context *models.TaskCreationContext) *models.AssistantResponse {
+	lower := strings.ToLower(message)
+	if isSkip(lower) {
+		context.TaskData.Assignee = nil
+		return ta.completeStep(context, "assignee", "due_date", prompts.DueDatePrompt)
+	}
+
+	lookupID := userIDRegex.FindString(message)
+	if selfWords[lower] {
+		lookupID = userID
+	}
+	if lookupID == "" {
+		return &models.AssistantResponse{
+			Message:     fmt.Sprintf("❌ Не удалось найти ID пользователя.\n\n%s", prompts.AssigneePrompt),
+			TaskContext: *context,
+		}
+	}
+
+	user, err := lookupUser(ctx, lookupID)
+	if err != nil {
+		log.Printf("Ошибка поиска исполнителя %s: %v", lookupID, err)
+		reason := "не удалось получить данные пользователя, попробуйте позже"
+		if errors.Is(err, auth.ErrUserNotFound) {
+			reason = "пользователь не найден"
+		}
+		return &models.AssistantResponse{
+			Message:     fmt.Sprintf("❌ %s\n\n%s", reason, prompts.AssigneePrompt),
+			TaskContext: *context,
+		}
+	}
+
+	context.TaskData.Assignee = &models.Assignee{
+		ID:       user.GetId(),
+		Name:     user.GetName(),
+		Lastname: user.GetLastname(),
+		Email:    user.GetEmail(),
+		Photo:    user.GetPhoto(),
+	}
+	return ta.completeStep(context, "assignee", "due_date", prompts.DueDatePrompt)
+}
+
+func (ta *TaskAssistant) handleEstimateStep(message string, context *models.TaskCreationContext) *models.AssistantResponse {
+	if isSkip(message) {
+		context.TaskData.EstimateHours = 0
+		return ta.completeStep(context, "estimate", "priority", prompts.PriorityPrompt)
+	}
+
+	hours, err := ParseEstimate(message)
+	if err != nil {
+		return &models.AssistantResponse{
+			Message:     fmt.Sprintf("❌ %s\n\n%s", err.Error(), prompts.EstimatePrompt),
+			TaskContext: *context,
+		}
+	}
+
+	context.TaskData.EstimateHours = hours
+	return ta.completeStep(context, "estimate", "priority", prompts.PriorityPrompt)
+}
+
+func (ta *TaskAssistant) handleConfirmationStep(message string, context *models.TaskCreationContext) *models.AssistantResponse {
+	switch strings.ToLower(message) {
+	case "да":
+		validationState := validator.ValidateTaskData(context.TaskData)
+		context.ValidationState = validationState
+		if !validationState.IsValid {
+			errorMessages := make([]string, 0, len(validationState.Errors))
+			for field, err := range validationState.Errors {
+				errorMessages = append(errorMessages, fmt.Sprintf("- %s: %s", field, err))
+			}
+			return &models.AssistantResponse{
+				Message:     fmt.Sprintf("❌ Обнаружены ошибки:\n%s", strings.Join(errorMessages, "\n")),
+				TaskContext: *context,
+			}
+		}
+
+		// Ключ сохраняется в контексте, чтобы повторное подтверждение не создало дубликат
+		if context.CreationKey == "" {
+			context.CreationKey = newCreationKey()
+		}
+
+		return &models.AssistantResponse{
+			Message:         "✅ Отлично! Создаю задачу...",
+			TaskContext:     *context,
+			SuggestedAction: "create_task",
+		}
+	case "нет":
+		context.CurrentStep = "title"
+		context.CreationKey = ""
+		return &models.AssistantResponse{
+			Message:     "Хорошо, давайте начнем сначала. Как назовем задачу?",
+			TaskContext: *context,
+		}
+	default:
+		return &models.AssistantResponse{
+			Message:     "Пожалуйста, ответьте 'да' или 'нет'.",
+			TaskContext: *context,
+		}
+	}
+}
+
+// newCreationKey создает случайный ключ идемпотентности для создания задачи
+func newCreationKey() string {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		// crypto/rand не должен возвращать ошибку; на всякий случай используем время
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(key)
+}
+
+// ParseEstimate переводит оценку вида "4 часа", "2д", "90 минут", "1.5h" в часы
+func ParseEstimate(message string) (float64, error) {
+	matches := estimateRegex.FindAllStringSubmatch(strings.ToLower(message), -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("не удалось распознать оценку")
+	}
+
+	var hours float64
+	for _, match := range matches {
+		value, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("не удалось распознать оценку")
+		}
+
+		unit := match[2]
+		switch {
+		case unit == "" || strings.HasPrefix(unit, "ч") || strings.HasPrefix(unit, "h"):
+			hours += value
+		case strings.HasPrefix(unit, "мин") || unit == "м" || unit == "m" || strings.HasPrefix(unit, "min"):
+			hours += value / 60
+		case strings.HasPrefix(unit, "нед") || strings.HasPrefix(unit, "w"):
+			hours += value * 5 * hoursPerDay
+		case strings.HasPrefix(unit, "д") || strings.HasPrefix(unit, "d"):
+			hours += value * hoursPerDay
+		default:
+			return 0, fmt.Errorf("неизвестная единица измерения: %s", unit)
+		}
+	}
+
+	return math.Round(hours*100) / 100, nil
+}
+
+func parseDueDate(message string) string {
+	switch strings.ToLower(message) {
+	case "сегодня":
+		return time.Now().Format("02.01.2006")
+	case "завтра":
+		return time.Now().AddDate(0, 0, 1).Format("02.01.2006")
+	}
+
+	if matches := dateRegex.FindStringSubmatch(message); len(matches) == 4 {
+		return fmt.Sprintf("%s.%s.%s", matches[1], matches[2], matches[3])
+	}
+	return message
+}
+
+func parsePriority(message string) string {
+	lower := strings.ToLower(message)
+	for _, pw := range priorityWords {
+		if strings.Contains(lower, pw.stem) {
+			return pw.priority
+		}
+	}
+	return strings.ToUpper(message)
+}
+
+// lookupUser находит пользователя в сервисе авторизации
+func lookupUser(ctx context.Context, userID string) (*auth_v1.User, error) {
+	return auth.GetUserByID(ctx, userID)
+}
+
+func isSkip(message string) bool {
+	return skipWords[strings.ToLower(message)]
+}
@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Jamolkhon5/mistral/internal/ai/task/models"
+)
+
+var projectIDRegex = regexp.MustCompile(`^(?:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|\d+)$`)
+
+const (
+	MinTitleLength       = 3
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 5000
+	MaxEstimateHours     = 1000
+)
+
+// ValidateTaskData проверяет все данные задачи
+func ValidateTaskData(data *models.TaskData) models.ValidationState {
+	state := models.ValidationState{
+		Errors:   make(map[string]string),
+		Warnings: make(map[string]string),
+	}
+
+	for _, step := range []string{"title", "description", "assignee", "due_date", "estimate", "priority", "project"} {
+		if err := ValidateTaskStep(step, data); err != nil {
+			state.Errors[step] = err.Error()
+		}
+	}
+
+	state.IsValid = len(state.Errors) == 0
+	return state
+}
+
+// ValidateTaskStep проверяет данные конкретного шага
+func ValidateTaskStep(step string, data *models.TaskData) error {
+	switch step {
+	case "title":
+		return validateTitle(data.Title)
+	case "description":
+		return validateDescription(data.Description)
+	case "assignee":
+		return validateAssignee(data.Assignee)
+	case "due_date":
+		return validateDueDate(data.DueDate)
+	case "estimate":
+		return validateEstimate(data.EstimateHours)
+	case "priority":
+		return validatePriority(data.Priority)
+	case "project":
+		return validateProjectID(data.ProjectID)
+	default:
+		return fmt.Errorf("неизвестный шаг создания задачи: %s", step)
+	}
+}
+
+func validateTitle(title string) error {
+	length := utf8.RuneCountInString(strings.TrimSpace(title))
+	if length < MinTitleLength {
+		return fmt.Errorf("название задачи должно содержать минимум %d символа", MinTitleLength)
+	}
+	if length > MaxTitleLength {
+		return fmt.Errorf("название задачи не может быть длиннее %d символов", MaxTitleLength)
+	}
+	return nil
+}
+
+func validateDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return fmt.Errorf("описание не может быть длиннее %d символов", MaxDescriptionLength)
+	}
+	return nil
+}
+
+func validateAssignee(assignee *models.Assignee) error {
+	if assignee == nil {
+		return nil
+	}
+	if assignee.ID == "" {
+		return fmt.Errorf("исполнитель не найден в системе")
+	}
+	return nil
+}
+
+func validateDueDate(dueDate string) error {
+	if dueDate == "" {
+		return fmt.Errorf("срок выполнения обязателен")
+	}
+
+	date, err := time.ParseInLocation("02.01.2006", dueDate, time.Local)
+	if err != nil {
+		return fmt.Errorf("неверный формат даты, используйте ДД.ММ.ГГГГ")
+	}
+
+	// Срок "сегодня" допустим, поэтому сравниваем с началом текущего дня в часовом поясе сервиса
+	year, month, day := time.Now().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	if date.Before(today) {
+		return fmt.Errorf("дата не может быть в прошлом")
+	}
+
+	return nil
+}
+
+func validateEstimate(hours float64) error {
+	if hours < 0 {
+		return fmt.Errorf("оценка не может быть отрицательной")
+	}
+	if hours > MaxEstimateHours {
+		return fmt.Errorf("оценка не может превышать %d часов, разбейте задачу на несколько", MaxEstimateHours)
+	}
+	return nil
+}
+
+func validatePriority(priority string) error {
+	switch strings.ToUpper(priority) {
+	case "ВЫСОКИЙ", "СРЕДНИЙ", "НИЗКИЙ":
+		return nil
+	default:
+		return fmt.Errorf("некорректный приоритет, допустимые значения: ВЫСОКИЙ, СРЕДНИЙ, НИЗКИЙ")
+	}
+}
+
+func validateProjectID(projectID string) error {
+	if projectID == "" {
+		return nil
+	}
+	if !projectIDRegex.MatchString(projectID) {
+		return fmt.Errorf("некорректный идентификатор проекта")
+	}
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -19,21 +21,54 @@ func InitClient(conn *grpc.ClientConn) {
 }
 
 func VerifyToken(r *http.Request) (string, error) {
+	user, err := VerifyUser(r)
+	if err != nil {
+		return "", err
+	}
+	return user.GetId(), nil
+}
+
+// VerifyUser проверяет токен и возвращает профиль пользователя из ответа сервиса авторизации.
+// Профиль кэшируется вместе с результатом проверки, поэтому язык и организацию пользователя
+// можно определить без отдельного запроса. Возвращенный профиль нельзя изменять.
+func VerifyUser(r *http.Request) (*auth_v1.User, error) {
 	authToken := r.Header.Get("Authorization")
 	if authToken == "" {
-		return "", fmt.Errorf("missing authorization header")
+		return nil, fmt.Errorf("missing authorization header")
 	}
 
+	return currentTokenCache().verify(authToken, func() (*auth_v1.User, error) {
+		return fetchUser(authToken)
+	}, isRejectedToken)
+}
+
+// fetchUser проверяет токен в сервисе авторизации. Запрос не привязан к контексту HTTP-запроса:
+// его результат могут ждать параллельные запросы с тем же токеном.
+func fetchUser(authToken string) (*auth_v1.User, error) {
 	md := metadata.New(map[string]string{
 		"Authorization": authToken,
 	})
-	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), md), requestTimeout)
+	defer cancel()
 
 	userInfo, err := gClient.GetUser(ctx, &emptypb.Empty{})
 	if err != nil {
 		log.Printf("Authentication failed: %v", err)
-		return "", err
+		return nil, err
+	}
+	if userInfo.GetUser() == nil {
+		return nil, ErrUserNotFound
 	}
 
-	return userInfo.GetUser().GetId(), nil
+	return userInfo.GetUser(), nil
+}
+
+// isRejectedToken сообщает, что сервис авторизации отклонил сам токен. Только такие ошибки
+// попадают в кэш: сбои сети и таймауты должны проверяться заново.
+func isRejectedToken(err error) bool {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return true
+	}
+	return false
 }
@@ -2,11 +2,18 @@ package auth
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	AuthAddr string `mapstructure:"AUTH"`
+
+	// Кэш проверки токенов; нулевые значения заменяются значениями по умолчанию
+	TokenCacheTTL         time.Duration `mapstructure:"TOKEN_CACHE_TTL"`
+	TokenCacheNegativeTTL time.Duration `mapstructure:"TOKEN_CACHE_NEGATIVE_TTL"` // Отрицательное значение отключает кэш недействительных токенов
+	TokenCacheSize        int           `mapstructure:"TOKEN_CACHE_SIZE"`
 }
 
 func NewConfig(path string) (*Config, error) {
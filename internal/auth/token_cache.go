@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
+)
+
+// Значения по умолчанию для кэша проверки токенов
+const (
+	DefaultTokenCacheTTL         = time.Minute
+	DefaultTokenCacheNegativeTTL = 5 * time.Second
+	DefaultTokenCacheSize        = 10000
+)
+
+// TokenCacheStats - счетчики кэша проверки токенов
+type TokenCacheStats struct {
+	Hits         int64 `json:"hits"`          // Токен найден в кэше
+	NegativeHits int64 `json:"negative_hits"` // Недействительный токен найден в кэше
+	Misses       int64 `json:"misses"`        // Понадобился запрос к сервису авторизации
+	Shared       int64 `json:"shared"`        // Запрос дождался уже выполняющейся проверки того же токена
+	Evictions    int64 `json:"evictions"`     // Записи, вытесненные из-за ограничения размера
+	Size         int   `json:"size"`
+}
+
+// tokenEntry - результат проверки токена: профиль пользователя или ошибка
+type tokenEntry struct {
+	key       string
+	user      *auth_v1.User
+	err       error
+	expiresAt time.Time
+}
+
+// tokenCall - выполняющаяся проверка токена, результат которой ждут параллельные запросы
+type tokenCall struct {
+	wg   sync.WaitGroup
+	user *auth_v1.User
+	err  error
+}
+
+// tokenCache хранит результаты проверки токенов. Ключом служит SHA-256 токена, чтобы сами
+// токены не оставались в памяти. Записи вытесняются по давности использования.
+type tokenCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	negativeTTL time.Duration
+	size        int
+	entries     map[string]*list.Element
+	order       *list.List
+	calls       map[string]*tokenCall
+
+	hits, negativeHits, misses, shared, evictions atomic.Int64
+}
+
+// newTokenCache создает кэш; нулевые ttl и size заменяются значениями по умолчанию,
+// отрицательный negativeTTL отключает кэширование недействительных токенов
+func newTokenCache(ttl, negativeTTL time.Duration, size int) *tokenCache {
+	if ttl <= 0 {
+		ttl = DefaultTokenCacheTTL
+	}
+	if negativeTTL == 0 {
+		negativeTTL = DefaultTokenCacheNegativeTTL
+	}
+	if size <= 0 {
+		size = DefaultTokenCacheSize
+	}
+	return &tokenCache{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		size:        size,
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+		calls:       make(map[string]*tokenCall),
+	}
+}
+
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// verify возвращает результат проверки токена из кэша или вызывает load. Параллельные
+// проверки одного токена выполняют один запрос. cacheError решает, можно ли запомнить ошибку.
+// Профиль из кэша общий для всех запросов с этим токеном и не должен изменяться.
+func (c *tokenCache) verify(token string, load func() (*auth_v1.User, error), cacheError func(error) bool) (*auth_v1.User, error) {
+	key := tokenKey(token)
+
+	c.mu.Lock()
+	if entry, ok := c.lookup(key); ok {
+		c.mu.Unlock()
+		if entry.err != nil {
+			c.negativeHits.Add(1)
+		} else {
+			c.hits.Add(1)
+		}
+		return entry.user, entry.err
+	}
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		c.shared.Add(1)
+		call.wg.Wait()
+		return call.user, call.err
+	}
+
+	call := &tokenCall{}
+	call.wg.Add(1)
+	c.calls[key] = call
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	call.user, call.err = load()
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	switch {
+	case call.err == nil:
+		c.store(key, call.user, nil, c.ttl)
+	case c.negativeTTL > 0 && cacheError(call.err):
+		c.store(key, nil, call.err, c.negativeTTL)
+	}
+	c.mu.Unlock()
+	call.wg.Done()
+
+	return call.user, call.err
+}
+
+// lookup возвращает действующую запись; истекшая запись удаляется. Вызывается под c.mu.
+func (c *tokenCache) lookup(key string) (*tokenEntry, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*tokenEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+// store сохраняет запись и вытесняет самые давно использованные при превышении размера.
+// Вызывается под c.mu.
+func (c *tokenCache) store(key string, user *auth_v1.User, err error, ttl time.Duration) {
+	entry := &tokenEntry{key: key, user: user, err: err, expiresAt: time.Now().Add(ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*tokenEntry).key)
+		c.evictions.Add(1)
+	}
+}
+
+func (c *tokenCache) stats() TokenCacheStats {
+	c.mu.Lock()
+	size := c.order.Len()
+	c.mu.Unlock()
+
+	return TokenCacheStats{
+		Hits:         c.hits.Load(),
+		NegativeHits: c.negativeHits.Load(),
+		Misses:       c.misses.Load(),
+		Shared:       c.shared.Load(),
+		Evictions:    c.evictions.Load(),
+		Size:         size,
+	}
+}
+
+var (
+	tokenCacheMu sync.RWMutex
+	tokens       = newTokenCache(0, 0, 0)
+)
+
+func init() {
+	// Счетчики доступны в /debug/vars служебного сервера под именем auth_token_cache
+	expvar.Publish("auth_token_cache", expvar.Func(func() any { return TokenCacheMetrics() }))
+}
+
+// ConfigureTokenCache заменяет кэш проверки токенов новым с указанными параметрами.
+// Нулевые значения означают значения по умолчанию, отрицательный negativeTTL
+// отключает кэширование недействительных токенов.
+func ConfigureTokenCache(ttl, negativeTTL time.Duration, size int) {
+	cache := newTokenCache(ttl, negativeTTL, size)
+
+	tokenCacheMu.Lock()
+	tokens = cache
+	tokenCacheMu.Unlock()
+}
+
+// TokenCacheMetrics возвращает текущие счетчики кэша проверки токенов
+func TokenCacheMetrics() TokenCacheStats {
+	return currentTokenCache().stats()
+}
+
+func currentTokenCache() *tokenCache {
+	tokenCacheMu.RLock()
+	defer tokenCacheMu.RUnlock()
+	return tokens
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Jamolkhon5/mistral/pkg/proto/auth_v1"
+)
+
+// requestTimeout ограничивает время ожидания ответа сервиса авторизации
+const requestTimeout = 5 * time.Second
+
+var ErrUserNotFound = errors.New("user not found")
+
+// GetUserByID возвращает пользователя по идентификатору
+func GetUserByID(ctx context.Context, id string) (*auth_v1.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := gClient.GetUserById(ctx, &auth_v1.GetUserByIdRequest{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("get user %s: %w", id, err)
+	}
+	if resp.GetUser() == nil || resp.GetUser().GetId() == "" {
+		return nil, ErrUserNotFound
+	}
+
+	return resp.GetUser(), nil
+}
+
+// GetUsersByIDs возвращает пользователей по списку идентификаторов одним запросом
+func GetUsersByIDs(ctx context.Context, ids []string) ([]*auth_v1.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := gClient.GetUsersByIds(ctx, &auth_v1.GetUsersByIdsRequest{Ids: ids})
+	if err != nil {
+		return nil, fmt.Errorf("get users by ids: %w", err)
+	}
+
+	return resp.GetUsers(), nil
+}
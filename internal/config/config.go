@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -13,6 +15,33 @@ type Config struct {
 	PgName        string `mapstructure:"PG_NAME"`
 	MistralApiKey string `mapstructure:"MISTRAL_API_KEY"`
 	ModelName     string `mapstructure:"MODEL_NAME"`
+
+	// Путь к JSON-описанию шагов мастера создания проекта; если не задан, используется встроенное
+	ProjectWizardPath string `mapstructure:"PROJECT_WIZARD_PATH"`
+
+	// Адрес сервиса задач; если не задан, используется http://task-service:5641
+	TaskServiceURL string `mapstructure:"TASK_SERVICE_URL"`
+
+	// Адрес сервиса проектов; если не задан, используется http://project-service:5641
+	ProjectServiceURL string `mapstructure:"PROJECT_SERVICE_URL"`
+
+	// Служебный токен для сервиса проектов, используется, если в запросе нет токена пользователя.
+	// Без него фоновый обработчик не запускается и неудачные попытки создания проекта
+	// повторяются только при повторном подтверждении пользователем.
+	ProjectServiceToken string `mapstructure:"PROJECT_SERVICE_TOKEN"`
+
+	// Страна производственного календаря для расчета рабочих дней; если не задана, используется RU
+	CalendarCountry string `mapstructure:"CALENDAR_COUNTRY"`
+
+	// Политика символов в названии и описании проекта: unicode (по умолчанию) или compat
+	ProjectTextPolicy string `mapstructure:"PROJECT_TEXT_POLICY"`
+
+	// Адрес служебного HTTP-сервера с метриками /debug/vars, например 127.0.0.1:5642.
+	// Не должен быть доступен снаружи; если не задан, метрики не публикуются.
+	AdminAddr string `mapstructure:"ADMIN_ADDR"`
+
+	// Срок хранения незавершенных черновиков проектов, например 720h; если не задан, 30 дней
+	ProjectDraftRetention time.Duration `mapstructure:"PROJECT_DRAFT_RETENTION"`
 }
 
 func NewConfig(path string) (*Config, error) {